@@ -4,13 +4,9 @@
 
 //go:build darwin || ios
 
-// Package apple 苹果部分系统下的专有接口
 package apple
 
 import (
-	"strings"
-	"unicode"
-
 	"github.com/issue9/localeutil/internal/defaults"
 	"github.com/issue9/localeutil/internal/syslocale"
 )
@@ -20,20 +16,46 @@ const appleLanguagesKey = "AppleLanguages"
 // AppLocale 返回 app 的界面语言
 //
 // app 表示该应用的 ID；
+// 返回值为 BCP 47 格式，转换规则可参考 [ParseLocale]。
 //
 // NOTE: macOS 系统中可以在设置中修改每个应用的语言，该接口可以获取此值。
 func AppLocale(app string) string {
-	v := defaults.Read(appleLanguagesKey, app)
+	if langs := AppLocales(app); len(langs) > 0 {
+		return langs[0]
+	}
+	return syslocale.Get()
+}
 
-	langs := strings.Split(strings.Trim(v, "()"), ",")
+// AppLocales 按优先级返回 app 的所有界面语言
+//
+// 与 [AppLocale] 不同，返回的是 AppleLanguages 的完整列表，
+// 如果 app 未设置界面语言，则返回 nil。
+// 返回值为 BCP 47 格式，无法转换的值将被忽略。
+func AppLocales(app string) []string {
+	ids := defaults.ReadArray(appleLanguagesKey, app)
+	langs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if tag, err := ParseLocale(id); err == nil {
+			langs = append(langs, tag.String())
+		}
+	}
 	if len(langs) == 0 {
-		return syslocale.Get()
+		return nil
 	}
-	return strings.TrimFunc(langs[0], func(r rune) bool {
-		return r == '"' || unicode.IsSpace(r)
-	})
+	return langs
 }
 
+// Preference 读取 domain 中 key 的值
+//
+// domain 可以是应用的 ID，也可以是 -g 表示全局设置，或是 plist 文件的路径，
+// 比如读取全局设置中的 AppleFirstWeekday：
+//
+//	v := apple.Preference("AppleFirstWeekday", "-g")
+//
+// 直接读取 plist 文件，刚通过 [SetAppLocale] 等方法写入的值可能要等 cfprefsd
+// 将其写入文件之后才能读取到。返回值的类型可参考 [plist] 包的说明，找不到时返回 nil。
+func Preference(key, domain string) any { return defaults.Value(key, domain) }
+
 // SetAppLocale 设置 app 的界面语言
 //
 // app 表示应用有的唯一 ID；
@@ -7,7 +7,9 @@
 package apple
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v4"
 )
@@ -16,8 +18,18 @@ func TestAppLocale(t *testing.T) {
 	a := assert.New(t, false)
 
 	app := "com.example.test"
+	langs := []string{"zh-CN", "zh-TW"}
 
-	a.NotError(SetAppLocale(app, "zh-CN", "zh-TW"))
-	id := AppLocale(app)
-	a.Equal(id, `zh-CN`)
+	a.NotError(SetAppLocale(app, langs...))
+
+	// 读取的是 plist 文件，需要等待 cfprefsd 将数据写入文件。
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if slices.Equal(AppLocales(app), langs) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	a.Equal(AppLocale(app), `zh-CN`).
+		Equal(AppLocales(app), langs)
 }
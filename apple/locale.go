@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// Package apple 苹果部分系统下的专有接口
+//
+// 其中 [ParseLocale] 不依赖系统接口，可以在任意平台下使用。
+package apple
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/issue9/localeutil/internal/syslocale"
+)
+
+// ParseLocale 将苹果系统中的本地化 ID 转换为 [language.Tag]
+//
+// 苹果系统采用 ICU 格式的 ID，比如 AppleLocale 的值可能为 en_US@rg=gbzzzz，
+// 这类值往往无法直接由 [language.Parse] 解析，转换规则如下：
+//   - _ 作为分隔符时转换为 -，比如 zh_CN 转换为 zh-CN；
+//   - @rg 转换为 -u-rg 扩展，比如 en_US@rg=gbzzzz 转换为 en-US-u-rg-gbzzzz；
+//   - calendar、numbers、collation 等关键字转换为对应的 -u- 扩展，
+//     比如 zh_CN@calendar=chinese;numbers=hanidec 转换为 zh-CN-u-ca-chinese-nu-hanidec；
+//
+// 无法识别的关键字会被忽略。
+func ParseLocale(id string) (language.Tag, error) { return syslocale.ParseApple(id) }
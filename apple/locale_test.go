@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package apple
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func TestParseLocale(t *testing.T) {
+	a := assert.New(t, false)
+
+	tag, err := ParseLocale("en_US@rg=gbzzzz")
+	a.NotError(err).Equal(tag, language.MustParse("en-US-u-rg-gbzzzz"))
+
+	tag, err = ParseLocale("zh_CN@calendar=chinese")
+	a.NotError(err).Equal(tag, language.MustParse("zh-CN-u-ca-chinese"))
+
+	_, err = ParseLocale("")
+	a.Error(err)
+}
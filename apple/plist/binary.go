@@ -0,0 +1,307 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package plist
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf16"
+)
+
+var binaryMagic = []byte("bplist00")
+
+const trailerSize = 32
+
+// 二进制格式中时间的起始点
+var appleEpoch = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type binaryDecoder struct {
+	data    []byte
+	offsets []uint64
+	refSize int
+
+	// 正在解析的对象，用于检测循环引用。
+	parsing map[uint64]struct{}
+
+	// 已经解析的对象
+	//
+	// 同一对象可能被多次引用，如果每次都重新解析，
+	// 在嵌套引用的情况下解析时间会呈指数级增长。
+	decoded map[uint64]any
+}
+
+// 二进制格式的结构如下：
+//
+//	header    bplist00
+//	objects   对象列表
+//	offsets   各个对象的偏移量
+//	trailer   32 字节，记录了 offsets 的位置以及根对象的序号等信息
+func decodeBinary(data []byte) (any, error) {
+	if len(data) < len(binaryMagic)+trailerSize {
+		return nil, errInvalidFormat
+	}
+
+	trailer := data[len(data)-trailerSize:]
+	offsetSize := int(trailer[6])
+	refSize := int(trailer[7])
+	numObjects := binary.BigEndian.Uint64(trailer[8:])
+	top := binary.BigEndian.Uint64(trailer[16:])
+	tableOffset := binary.BigEndian.Uint64(trailer[24:])
+
+	if offsetSize == 0 || offsetSize > 8 || refSize == 0 || refSize > 8 || top >= numObjects {
+		return nil, errInvalidFormat
+	}
+	end := uint64(len(data) - trailerSize)
+	if tableOffset >= end || numObjects > (end-tableOffset)/uint64(offsetSize) {
+		return nil, errInvalidFormat
+	}
+
+	d := &binaryDecoder{
+		data:    data[:end],
+		offsets: make([]uint64, numObjects),
+		refSize: refSize,
+		parsing: make(map[uint64]struct{}, 10),
+		decoded: make(map[uint64]any, numObjects),
+	}
+	for i := range d.offsets {
+		start := tableOffset + uint64(i*offsetSize)
+		d.offsets[i] = readUint(data[start : start+uint64(offsetSize)])
+	}
+
+	return d.object(top)
+}
+
+func (d *binaryDecoder) object(ref uint64) (any, error) {
+	if ref >= uint64(len(d.offsets)) {
+		return nil, fmt.Errorf("plist: invalid object reference %d", ref)
+	}
+	if v, found := d.decoded[ref]; found {
+		return v, nil
+	}
+	if _, found := d.parsing[ref]; found {
+		return nil, fmt.Errorf("plist: circular object reference %d", ref)
+	}
+	d.parsing[ref] = struct{}{}
+	defer delete(d.parsing, ref)
+
+	v, err := d.decode(ref)
+	if err != nil {
+		return nil, err
+	}
+	d.decoded[ref] = v
+	return v, nil
+}
+
+func (d *binaryDecoder) decode(ref uint64) (any, error) {
+	off := d.offsets[ref]
+	if off >= uint64(len(d.data)) {
+		return nil, errInvalidFormat
+	}
+	marker := d.data[off]
+	typ, info := marker>>4, marker&0x0f
+	off++
+
+	switch typ {
+	case 0x0:
+		switch info {
+		case 0x8:
+			return false, nil
+		case 0x9:
+			return true, nil
+		default: // null 和 fill
+			return nil, nil
+		}
+	case 0x1:
+		size := uint64(1) << info
+		b, err := d.bytes(off, size)
+		if err != nil {
+			return nil, err
+		}
+		if size > 8 { // 128 位的整数，仅取低 64 位。
+			b = b[size-8:]
+		}
+		if size < 8 { // 小于 8 个字节的整数都是无符号的
+			return int64(readUint(b)), nil
+		}
+		return int64(binary.BigEndian.Uint64(b)), nil
+	case 0x2:
+		return d.real(off, uint64(1)<<info)
+	case 0x3:
+		v, err := d.real(off, 8)
+		if err != nil {
+			return nil, err
+		}
+		sec, frac := math.Modf(v)
+		return appleEpoch.Add(time.Duration(sec)*time.Second + time.Duration(frac*float64(time.Second))), nil
+	case 0x4:
+		size, off, err := d.size(info, off)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(off, size)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 0x5:
+		size, off, err := d.size(info, off)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(off, size)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case 0x6:
+		size, off, err := d.size(info, off)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.bytes(off, size*2)
+		if err != nil {
+			return nil, err
+		}
+		u16 := make([]uint16, size)
+		for i := range u16 {
+			u16[i] = binary.BigEndian.Uint16(b[i*2:])
+		}
+		return string(utf16.Decode(u16)), nil
+	case 0x8:
+		b, err := d.bytes(off, uint64(info)+1)
+		if err != nil {
+			return nil, err
+		}
+		return readUint(b), nil
+	case 0xa, 0xc:
+		size, off, err := d.size(info, off)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := d.refs(off, size)
+		if err != nil {
+			return nil, err
+		}
+		arr := make([]any, 0, len(refs))
+		for _, r := range refs {
+			v, err := d.object(r)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	case 0xd:
+		size, off, err := d.size(info, off)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := d.refs(off, size*2)
+		if err != nil {
+			return nil, err
+		}
+		dict := make(map[string]any, size)
+		for i := uint64(0); i < size; i++ {
+			k, err := d.object(refs[i])
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("plist: invalid dict key type %T", k)
+			}
+
+			v, err := d.object(refs[size+i])
+			if err != nil {
+				return nil, err
+			}
+			dict[key] = v
+		}
+		return dict, nil
+	default:
+		return nil, fmt.Errorf("plist: unsupported object type %#x", marker)
+	}
+}
+
+// 返回对象的长度及长度之后的偏移量
+//
+// info 小于 0xf 时即为长度，否则长度为紧随其后的整数对象。
+func (d *binaryDecoder) size(info byte, off uint64) (size, next uint64, err error) {
+	if info != 0x0f {
+		return uint64(info), off, nil
+	}
+
+	b, err := d.bytes(off, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if b[0]>>4 != 0x1 {
+		return 0, 0, errInvalidFormat
+	}
+
+	n := uint64(1) << (b[0] & 0x0f)
+	if n > 8 {
+		return 0, 0, errInvalidFormat
+	}
+	b, err = d.bytes(off+1, n)
+	if err != nil {
+		return 0, 0, err
+	}
+	if size = readUint(b); size > uint64(len(d.data)) {
+		return 0, 0, errInvalidFormat
+	}
+	return size, off + 1 + n, nil
+}
+
+func (d *binaryDecoder) refs(off, count uint64) ([]uint64, error) {
+	if count > uint64(len(d.data))/uint64(d.refSize) {
+		return nil, errInvalidFormat
+	}
+
+	b, err := d.bytes(off, count*uint64(d.refSize))
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]uint64, count)
+	for i := range refs {
+		refs[i] = readUint(b[i*d.refSize : (i+1)*d.refSize])
+	}
+	return refs, nil
+}
+
+func (d *binaryDecoder) real(off, size uint64) (float64, error) {
+	b, err := d.bytes(off, size)
+	if err != nil {
+		return 0, err
+	}
+
+	switch size {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	default:
+		return 0, errInvalidFormat
+	}
+}
+
+func (d *binaryDecoder) bytes(off, size uint64) ([]byte, error) {
+	if off > uint64(len(d.data)) || size > uint64(len(d.data))-off {
+		return nil, errInvalidFormat
+	}
+	return d.data[off : off+size], nil
+}
+
+// 读取大端序的无符号整数，b 的长度不能超过 8。
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// Package plist 苹果属性列表文件的读取
+//
+// 支持二进制(bplist00)和 XML 两种格式，不依赖系统的 defaults 命令，可以在任意平台下使用。
+//
+// 解析后的值类型对应关系如下：
+//
+//	dict    map[string]any
+//	array   []any
+//	string  string
+//	integer int64
+//	real    float64
+//	true    bool
+//	false   bool
+//	date    time.Time
+//	data    []byte
+//
+// 二进制格式中的 set 会被当作 array 处理，UID 则以 uint64 表示。
+package plist
+
+import (
+	"bytes"
+	"errors"
+	"os"
+)
+
+var errInvalidFormat = errors.New("plist: invalid format")
+
+// Decode 解析 plist 格式的内容
+//
+// 根据 data 的内容自动判断是二进制格式还是 XML 格式。
+func Decode(data []byte) (any, error) {
+	if bytes.HasPrefix(data, binaryMagic) {
+		return decodeBinary(data)
+	}
+	return decodeXML(data)
+}
+
+// ReadFile 读取并解析 plist 文件
+func ReadFile(path string) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(data)
+}
+
+// Lookup 查找 v 中 key 对应的值
+//
+// v 必须为 map[string]any，否则返回 nil。
+func Lookup(v any, key string) any {
+	if dict, ok := v.(map[string]any); ok {
+		return dict[key]
+	}
+	return nil
+}
+
+// Strings 将 v 转换为字符串列表
+//
+// v 可以是 string 或是元素为 string 的 []any，其它类型返回 nil。
+func Strings(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []any:
+		strs := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package plist
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestReadFile(t *testing.T) {
+	a := assert.New(t, false)
+
+	for _, file := range []string{"./testdata/global.binary.plist", "./testdata/global.xml.plist"} {
+		v, err := ReadFile(file)
+		a.NotError(err, file).NotNil(v)
+
+		dict, ok := v.(map[string]any)
+		a.True(ok).
+			Equal(Strings(dict["AppleLanguages"]), []string{"zh-Hans-CN", "en-US", "ja-JP"}).
+			Equal(dict["AppleLocale"], "zh_CN@rg=uszzzz").
+			Equal(dict["AppleMetricUnits"], true).
+			Equal(dict["AppleFirstWeekday"], map[string]any{"gregorian": int64(2)}).
+			Equal(dict["Count"], int64(-3)).
+			Equal(dict["Big"], int64(1<<40)).
+			Equal(dict["Ratio"], 1.5).
+			Equal(dict["Data"], []byte{0, 1, 2}).
+			Equal(dict["Unicode"], "汉字")
+
+		date, ok := dict["Date"].(time.Time)
+		a.True(ok).True(date.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), file)
+	}
+
+	v, err := ReadFile("./testdata/not-exists.plist")
+	a.ErrorIs(err, os.ErrNotExist).Nil(v)
+}
+
+func TestDecode(t *testing.T) {
+	a := assert.New(t, false)
+
+	v, err := Decode([]byte(`<plist><array><string>a</string><true/><real>2</real></array></plist>`))
+	a.NotError(err).Equal(v, []any{"a", true, 2.0})
+
+	v, err = Decode([]byte(`<?xml version="1.0"?><plist><dict></dict></plist>`))
+	a.NotError(err).Equal(v, map[string]any{})
+
+	_, err = Decode([]byte(`<dict></dict>`))
+	a.Error(err)
+
+	_, err = Decode([]byte(`<plist><dict><string>a</string></dict></plist>`))
+	a.Error(err)
+
+	_, err = Decode([]byte(`<plist><integer>abc</integer></plist>`))
+	a.Error(err)
+
+	// 二进制格式的错误数据
+	data, err := os.ReadFile("./testdata/global.binary.plist")
+	a.NotError(err)
+
+	_, err = Decode(data[:40])
+	a.Error(err)
+
+	broken := append([]byte(nil), data...)
+	broken[len(broken)-1] = 0xff // 偏移量表的位置
+	_, err = Decode(broken)
+	a.Error(err)
+
+	broken = append([]byte(nil), data...)
+	broken[len(broken)-9] = 0xff // 根对象的序号
+	_, err = Decode(broken)
+	a.Error(err)
+}
+
+func TestDecode_circular(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 数组引用了自身
+	data := []byte("bplist00")
+	data = append(data, 0xa1, 0x00) // array，包含一个元素，引用对象 0
+	data = append(data, 0x08)       // offsets
+	trailer := make([]byte, trailerSize)
+	trailer[6] = 1  // offsetSize
+	trailer[7] = 1  // refSize
+	trailer[15] = 1 // numObjects
+	trailer[31] = 10
+	data = append(data, trailer...)
+
+	_, err := Decode(data)
+	a.Error(err)
+}
+
+func TestDecode_shared(t *testing.T) {
+	a := assert.New(t, false)
+
+	// 30 个数组，每个数组都引用了两次下一个数组，最后一个对象为整数 1。
+	const arrays = 30
+	data := []byte("bplist00")
+	offsets := make([]byte, 0, arrays+1)
+	for i := range arrays {
+		offsets = append(offsets, byte(len(data)))
+		data = append(data, 0xa2, byte(i+1), byte(i+1))
+	}
+	offsets = append(offsets, byte(len(data)))
+	data = append(data, 0x10, 0x01)
+
+	tableOffset := len(data)
+	data = append(data, offsets...)
+	trailer := make([]byte, trailerSize)
+	trailer[6] = 1 // offsetSize
+	trailer[7] = 1 // refSize
+	trailer[15] = arrays + 1
+	trailer[31] = byte(tableOffset)
+	data = append(data, trailer...)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		v, err := Decode(data)
+		a.NotError(err).NotNil(v)
+		for range arrays {
+			arr, ok := v.([]any)
+			a.True(ok).Length(arr, 2)
+			v = arr[0]
+		}
+		a.Equal(v, int64(1))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("解析超时")
+	}
+}
+
+func TestParseInteger(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []struct {
+		text string
+		v    int64
+		err  bool
+	}{
+		{text: "10", v: 10},
+		{text: "010", v: 10},
+		{text: "-10", v: -10},
+		{text: "+10", v: 10},
+		{text: "0x10", v: 16},
+		{text: "-0x10", v: -16},
+		{text: "18446744073709551615", v: -1},
+		{text: "1_000", err: true},
+		{text: "0b1", err: true},
+		{text: "0o7", err: true},
+		{text: "0x", err: true},
+		{text: "", err: true},
+	}
+
+	for _, item := range data {
+		v, err := parseInteger(item.text)
+		if item.err {
+			a.Error(err, item.text)
+		} else {
+			a.NotError(err, item.text).Equal(v, item.v, item.text)
+		}
+	}
+
+	v, err := Decode([]byte(`<?xml version="1.0" encoding="UTF-8"?><plist version="1.0"><integer>010</integer></plist>`))
+	a.NotError(err).Equal(v, int64(10))
+}
+
+func TestLookup(t *testing.T) {
+	a := assert.New(t, false)
+
+	dict := map[string]any{"k1": "v1", "k2": []any{"v1", 2, "v2"}}
+	a.Equal(Lookup(dict, "k1"), "v1").
+		Nil(Lookup(dict, "k3")).
+		Nil(Lookup([]any{}, "k1"))
+
+	a.Equal(Strings(Lookup(dict, "k1")), []string{"v1"}).
+		Equal(Strings(Lookup(dict, "k2")), []string{"v1", "v2"}).
+		Nil(Strings(5))
+}
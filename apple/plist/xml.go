@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type xmlDecoder struct {
+	d *xml.Decoder
+}
+
+func decodeXML(data []byte) (any, error) {
+	d := &xmlDecoder{d: xml.NewDecoder(bytes.NewReader(data))}
+
+	start, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	if start.Name.Local != "plist" {
+		return nil, errInvalidFormat
+	}
+
+	start, err = d.next()
+	if err != nil {
+		return nil, err
+	}
+	return d.value(start)
+}
+
+// 返回下一个开始标签
+func (d *xmlDecoder) next() (xml.StartElement, error) {
+	for {
+		t, err := d.d.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			return tt, nil
+		case xml.EndElement:
+			return xml.StartElement{}, errInvalidFormat
+		}
+	}
+}
+
+// 解析以 start 开头的元素
+func (d *xmlDecoder) value(start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict":
+		return d.dict()
+	case "array":
+		return d.array()
+	case "true", "false":
+		if err := d.d.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+	}
+
+	text, err := d.text()
+	if err != nil {
+		return nil, err
+	}
+
+	switch start.Name.Local {
+	case "string", "key":
+		return text, nil
+	case "integer":
+		return parseInteger(strings.TrimSpace(text))
+	case "real":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "date":
+		return time.Parse(time.RFC3339, strings.TrimSpace(text))
+	case "data":
+		return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+	default:
+		return nil, fmt.Errorf("plist: unsupported element %s", start.Name.Local)
+	}
+}
+
+func (d *xmlDecoder) dict() (map[string]any, error) {
+	dict := make(map[string]any, 10)
+	for {
+		key, end, err := d.child()
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return dict, nil
+		}
+		if key.Name.Local != "key" {
+			return nil, errInvalidFormat
+		}
+		k, err := d.text()
+		if err != nil {
+			return nil, err
+		}
+
+		start, end, err := d.child()
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return nil, errInvalidFormat
+		}
+		v, err := d.value(start)
+		if err != nil {
+			return nil, err
+		}
+		dict[k] = v
+	}
+}
+
+func (d *xmlDecoder) array() ([]any, error) {
+	arr := make([]any, 0, 10)
+	for {
+		start, end, err := d.child()
+		if err != nil {
+			return nil, err
+		}
+		if end {
+			return arr, nil
+		}
+
+		v, err := d.value(start)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+}
+
+// 返回下一个子元素，如果遇到父元素的结束标签，end 为 true。
+func (d *xmlDecoder) child() (start xml.StartElement, end bool, err error) {
+	for {
+		t, err := d.d.Token()
+		if err != nil {
+			return xml.StartElement{}, false, err
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			return tt, false, nil
+		case xml.EndElement:
+			return xml.StartElement{}, true, nil
+		}
+	}
+}
+
+// 读取元素的文本内容，直到元素结束。
+func (d *xmlDecoder) text() (string, error) {
+	b := &strings.Builder{}
+	for {
+		t, err := d.d.Token()
+		if err != nil {
+			return "", err
+		}
+
+		switch tt := t.(type) {
+		case xml.CharData:
+			b.Write(tt)
+		case xml.EndElement:
+			return b.String(), nil
+		case xml.StartElement:
+			return "", errInvalidFormat
+		}
+	}
+}
+
+// 解析 integer 元素的内容
+//
+// 以十进制解析，只有明确指定了 0x 前缀时才作为十六进制处理，
+// 所以 010 表示的是 10 而不是八进制的 8。
+func parseInteger(text string) (int64, error) {
+	base := 10
+	digits := text
+	sign := ""
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if hex, found := strings.CutPrefix(digits, "0x"); found {
+		base, digits = 16, hex
+	} else if hex, found := strings.CutPrefix(digits, "0X"); found {
+		base, digits = 16, hex
+	}
+
+	if v, err := strconv.ParseInt(sign+digits, base, 64); err == nil {
+		return v, nil
+	}
+	if sign == "-" {
+		return 0, fmt.Errorf("plist: invalid integer %s", text)
+	}
+	v, err := strconv.ParseUint(digits, base, 64) // 超过 int64 的正整数
+	if err != nil {
+		return 0, fmt.Errorf("plist: invalid integer %s", text)
+	}
+	return int64(v), nil
+}
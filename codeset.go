@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"errors"
+	"io"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+
+	"github.com/issue9/localeutil/internal/syslocale"
+)
+
+var errUnknownCodeset = errors.New("localeutil: unknown codeset")
+
+// 常见的 POSIX 编码名称与 [encoding.Encoding] 的对应关系
+//
+// 键名为规范化之后的名称，即仅保留字母和数字且字母为小写，比如 UTF-8 对应 utf8。
+var codesets = map[string]encoding.Encoding{
+	"utf8": unicode.UTF8,
+
+	"gb18030": simplifiedchinese.GB18030,
+	"gbk":     simplifiedchinese.GBK,
+	"gb2312":  simplifiedchinese.GBK, // EUC-CN 是 GBK 的子集
+	"euccn":   simplifiedchinese.GBK,
+
+	"big5":      traditionalchinese.Big5,
+	"big5hkscs": traditionalchinese.Big5,
+
+	"eucjp":     japanese.EUCJP,
+	"sjis":      japanese.ShiftJIS,
+	"shiftjis":  japanese.ShiftJIS,
+	"iso2022jp": japanese.ISO2022JP,
+
+	"euckr": korean.EUCKR,
+
+	"iso88591":  charmap.ISO8859_1,
+	"iso88592":  charmap.ISO8859_2,
+	"iso88593":  charmap.ISO8859_3,
+	"iso88594":  charmap.ISO8859_4,
+	"iso88595":  charmap.ISO8859_5,
+	"iso88596":  charmap.ISO8859_6,
+	"iso88597":  charmap.ISO8859_7,
+	"iso88598":  charmap.ISO8859_8,
+	"iso88599":  charmap.ISO8859_9,
+	"iso885910": charmap.ISO8859_10,
+	"iso885913": charmap.ISO8859_13,
+	"iso885914": charmap.ISO8859_14,
+	"iso885915": charmap.ISO8859_15,
+	"iso885916": charmap.ISO8859_16,
+
+	"koi8r":  charmap.KOI8R,
+	"koi8u":  charmap.KOI8U,
+	"cp1251": charmap.Windows1251,
+	"cp1252": charmap.Windows1252,
+	"tis620": charmap.Windows874,
+}
+
+// DetectCodeset 检测当前用户终端的编码名称
+//
+// 按 POSIX 的规则依次读取 LC_ALL、LC_CTYPE 和 LANG 中的编码部分，
+// 比如 zh_CN.GB18030 返回 GB18030，未指定编码时返回空字符串。
+// 返回值为原始值，可以通过 [CodesetEncoding] 转换为 [encoding.Encoding]。
+//
+// NOTE: windows 等平台的系统接口返回的本地化信息不包含编码，
+// 在这些平台上仅能从 LC_ALL、LC_CTYPE 和 LANG 环境变量中获取编码，未设置时返回空字符串。
+func DetectCodeset() string { return defaultDetector.Codeset() }
+
+// CodesetEncoding 返回编码名称 codeset 对应的 [encoding.Encoding]
+//
+// codeset 为 POSIX 格式中的编码名称，比如 UTF-8、GB18030、eucJP 等，不区分大小写以及 - 和 _；
+// 也可以是 IANA 中登记的名称或别名。
+func CodesetEncoding(codeset string) (encoding.Encoding, error) {
+	if e, found := codesets[syslocale.NormalizeCodeset(codeset)]; found {
+		return e, nil
+	}
+
+	if e, err := ianaindex.IANA.Encoding(codeset); err == nil && e != nil {
+		return e, nil
+	}
+
+	return nil, errUnknownCodeset
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// NewCodesetWriter 返回以 codeset 编码写入 w 的 [io.WriteCloser]
+//
+// 写入的内容应该是 UTF-8 编码，无法用 codeset 表示的字符将被替换。
+// 转换时可能会缓存未完整的字符，写入完成之后需要调用 Close 输出这些内容，
+// Close 不会关闭 w。codeset 为 UTF-8 时 Close 不执行任何操作。
+func NewCodesetWriter(w io.Writer, codeset string) (io.WriteCloser, error) {
+	e, err := CodesetEncoding(codeset)
+	if err != nil {
+		return nil, err
+	}
+	if e == unicode.UTF8 {
+		return nopWriteCloser{Writer: w}, nil
+	}
+	return transform.NewWriter(w, encoding.ReplaceUnsupported(e.NewEncoder())), nil
+}
+
+// NewCodesetReader 返回将 codeset 编码的 r 转换为 UTF-8 的 [io.Reader]
+//
+// codeset 为 UTF-8 时直接返回 r。
+func NewCodesetReader(r io.Reader, codeset string) (io.Reader, error) {
+	e, err := CodesetEncoding(codeset)
+	if err != nil {
+		return nil, err
+	}
+	if e == unicode.UTF8 {
+		return r, nil
+	}
+	return e.NewDecoder().Reader(r), nil
+}
+
+// NewTerminalWriter 返回以当前终端编码写入 w 的 [io.WriteCloser]
+//
+// 编码由 [DetectCodeset] 检测，未指定编码或是无法识别的编码时原样写入 w。
+// 写入完成之后需要调用 Close，具体可参考 [NewCodesetWriter]。
+// 可以与 [Printer] 配合使用，保证输出的内容在非 UTF-8 的终端中正确显示：
+//
+//	w := localeutil.NewTerminalWriter(os.Stdout)
+//	defer w.Close()
+//	p.Fprintf(w, "hello")
+func NewTerminalWriter(w io.Writer) io.WriteCloser {
+	if ww, err := NewCodesetWriter(w, DetectCodeset()); err == nil {
+		return ww
+	}
+	return nopWriteCloser{Writer: w}
+}
+
+// NewTerminalReader 返回将当前终端编码的 r 转换为 UTF-8 的 [io.Reader]
+//
+// 编码由 [DetectCodeset] 检测，未指定编码或是无法识别的编码直接返回 r。
+func NewTerminalReader(r io.Reader) io.Reader {
+	if rr, err := NewCodesetReader(r, DetectCodeset()); err == nil {
+		return rr
+	}
+	return r
+}
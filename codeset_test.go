@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestCodesetEncoding(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := map[string]any{
+		"UTF-8":        unicode.UTF8,
+		"utf8":         unicode.UTF8,
+		"GB18030":      simplifiedchinese.GB18030,
+		"gb2312":       simplifiedchinese.GBK,
+		"eucJP":        japanese.EUCJP,
+		"EUC-JP":       japanese.EUCJP,
+		"ISO-8859-1":   charmap.ISO8859_1,
+		"KOI8-R":       charmap.KOI8R,
+		"Shift_JIS":    japanese.ShiftJIS,
+		"windows-1250": charmap.Windows1250, // IANA
+	}
+	for name, enc := range data {
+		e, err := CodesetEncoding(name)
+		a.NotError(err, name).Equal(e, enc, name)
+	}
+
+	e, err := CodesetEncoding("not-exists")
+	a.Equal(err, errUnknownCodeset).Nil(e)
+
+	_, err = CodesetEncoding("")
+	a.Equal(err, errUnknownCodeset)
+}
+
+func TestNewCodesetWriter(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := &bytes.Buffer{}
+	w, err := NewCodesetWriter(buf, "UTF-8")
+	a.NotError(err).Equal(w, nopWriteCloser{Writer: buf})
+	_, err = io.WriteString(w, "中文")
+	a.NotError(err).NotError(w.Close()).Equal(buf.String(), "中文")
+
+	buf.Reset()
+
+	w, err = NewCodesetWriter(buf, "GB18030")
+	a.NotError(err)
+	_, err = io.WriteString(w, "中文")
+	a.NotError(err).NotError(w.Close()).Equal(buf.Bytes(), []byte{0xd6, 0xd0, 0xce, 0xc4})
+
+	// 不完整的字符由 Close 输出
+	buf.Reset()
+	w, err = NewCodesetWriter(buf, "GB18030")
+	a.NotError(err)
+	_, err = w.Write([]byte("中文")[:4])
+	a.NotError(err).Equal(buf.Bytes(), []byte{0xd6, 0xd0})
+	a.NotError(w.Close()).Equal(buf.Bytes(), []byte{0xd6, 0xd0, 0x84, 0x31, 0xa4, 0x37})
+
+	// 无法表示的字符
+	buf.Reset()
+	w, err = NewCodesetWriter(buf, "ISO-8859-1")
+	a.NotError(err)
+	_, err = io.WriteString(w, "a中")
+	a.NotError(err).NotError(w.Close()).Equal(buf.String(), "a\x1a")
+
+	w, err = NewCodesetWriter(buf, "not-exists")
+	a.Equal(err, errUnknownCodeset).Nil(w)
+}
+
+func TestNewCodesetReader(t *testing.T) {
+	a := assert.New(t, false)
+
+	src := strings.NewReader("abc")
+	r, err := NewCodesetReader(src, "utf8")
+	a.NotError(err).Equal(r, src)
+
+	r, err = NewCodesetReader(bytes.NewReader([]byte{0xd6, 0xd0, 0xce, 0xc4}), "gb18030")
+	a.NotError(err)
+	data, err := io.ReadAll(r)
+	a.NotError(err).Equal(string(data), "中文")
+
+	r, err = NewCodesetReader(src, "not-exists")
+	a.Equal(err, errUnknownCodeset).Nil(r)
+}
+
+func TestNewTerminalWriter(t *testing.T) {
+	a := assert.New(t, false)
+
+	t.Setenv("LC_ALL", "zh_CN.GB18030")
+	a.Equal(DetectCodeset(), "GB18030")
+
+	buf := &bytes.Buffer{}
+	p := message.NewPrinter(language.SimplifiedChinese, message.Catalog(message.DefaultCatalog))
+	w := NewTerminalWriter(buf)
+	p.Fprintf(w, "中%d", 1)
+	a.NotError(w.Close()).Equal(buf.Bytes(), []byte{0xd6, 0xd0, '1'})
+
+	r := NewTerminalReader(bytes.NewReader(buf.Bytes()))
+	data, err := io.ReadAll(r)
+	a.NotError(err).Equal(string(data), "中1")
+
+	// 未知编码
+	t.Setenv("LC_ALL", "zh_CN.unknown")
+	buf.Reset()
+	a.Equal(NewTerminalWriter(buf), nopWriteCloser{Writer: buf})
+	src := strings.NewReader("abc")
+	a.Equal(NewTerminalReader(src), src)
+}
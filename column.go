@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// 未结束的转义序列的最大长度
+//
+// 超过此长度的转义序列，比如缺少结束符的 OSC 序列，其起始字符会被当作控制字符处理，
+// 之后的内容则作为普通的文本，以防止 pending 无限增长。
+const maxPendingEscape = 4096
+
+// ColumnWriter 记录光标位置的 [io.Writer] 实现
+//
+// 所有写入的内容都会原样输出到底层的 [io.Writer]，同时记录光标所在的列和行，
+// 适用于进度条、日志格式化等需要知道当前光标位置的场景。
+//
+// 宽度的计算规则与 [WidthOptions.VisibleWidth] 相同，此外还会处理以下控制字符：
+//   - \n 换行，列号归零；
+//   - \r 列号归零；
+//   - \t 移至下一个制表位；
+//   - \b 列号减一；
+//
+// 被拆分在多次 Write 中的 UTF-8 字符、字符簇以及转义序列都能正确处理。
+type ColumnWriter struct {
+	w       io.Writer
+	wo      WidthOptions
+	tabSize int
+
+	cw      clusterWidth
+	column  int // 不包含 cw 中未结束的字符簇
+	line    int
+	pending string // 未完整的 UTF-8 字符或是转义序列
+}
+
+// NewColumnWriter 声明 [ColumnWriter] 对象
+//
+// tabSize 为制表位的宽度，如果小于等于 0，则采用 8。
+func (wo WidthOptions) NewColumnWriter(w io.Writer, tabSize int) *ColumnWriter {
+	if tabSize <= 0 {
+		tabSize = 8
+	}
+
+	return &ColumnWriter{
+		w:       w,
+		wo:      wo,
+		tabSize: tabSize,
+		cw:      wo.newClusterWidth(),
+	}
+}
+
+func (w *ColumnWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.track(p[:n])
+	return n, err
+}
+
+func (w *ColumnWriter) track(p []byte) {
+	s := w.pending + string(p)
+	w.pending = ""
+
+	for s != "" {
+		if n, complete := parseEscape(s); n > 0 {
+			if !complete && len(s) < maxPendingEscape {
+				w.pending = s
+				return
+			}
+			if !complete { // 超过长度限制，仅跳过起始字符。
+				_, n = utf8.DecodeRuneInString(s)
+			}
+			w.control(s[0])
+			s = s[n:]
+			continue
+		}
+
+		if !utf8.FullRuneInString(s) {
+			w.pending = s
+			return
+		}
+
+		r, n := utf8.DecodeRuneInString(s)
+		prev, _ := w.cw.add(r)
+		w.column += prev
+		s = s[n:]
+	}
+}
+
+// 处理控制字符和转义序列，c 为其第一个字节。
+func (w *ColumnWriter) control(c byte) {
+	col := w.Column()
+	w.cw = w.wo.newClusterWidth()
+
+	switch c {
+	case '\n':
+		w.line++
+		w.column = 0
+	case '\r':
+		w.column = 0
+	case '\t':
+		w.column = (col/w.tabSize + 1) * w.tabSize
+	case '\b':
+		w.column = max(col-1, 0)
+	default:
+		w.column = col
+	}
+}
+
+// Column 光标所在的列
+//
+// 从 0 开始计算，也即当前行已经输出内容的宽度。
+func (w *ColumnWriter) Column() int { return w.column + w.cw.w }
+
+// Line 光标所在的行
+//
+// 从 0 开始计算，也即已经输出的换行符数量。
+func (w *ColumnWriter) Line() int { return w.line }
+
+// NewColumnWriter 采用 [DefaultWidthOptions] 声明 [ColumnWriter] 对象
+//
+// 具体说明可参考 [WidthOptions.NewColumnWriter]。
+func NewColumnWriter(w io.Writer, tabSize int) *ColumnWriter {
+	return defaultWidthOptions.NewColumnWriter(w, tabSize)
+}
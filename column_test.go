@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/width"
+)
+
+var _ io.Writer = &ColumnWriter{}
+
+func TestColumnWriter(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := &bytes.Buffer{}
+	w := NewColumnWriter(buf, 0)
+	a.Equal(w.Column(), 0).Equal(w.Line(), 0)
+
+	fmt.Fprint(w, "abc")
+	a.Equal(w.Column(), 3).Equal(w.Line(), 0)
+
+	fmt.Fprint(w, "汉字\n")
+	a.Equal(w.Column(), 0).Equal(w.Line(), 1)
+
+	fmt.Fprint(w, "a\tb")
+	a.Equal(w.Column(), 9)
+
+	fmt.Fprint(w, "\t")
+	a.Equal(w.Column(), 16)
+
+	fmt.Fprint(w, "\r\x1b[31m汉\x1b[0m")
+	a.Equal(w.Column(), 2)
+
+	fmt.Fprint(w, "\b")
+	a.Equal(w.Column(), 1)
+
+	fmt.Fprint(w, "\r\n\n")
+	a.Equal(w.Column(), 0).Equal(w.Line(), 3)
+
+	a.Equal(buf.String(), "abc汉字\na\tb\t\r\x1b[31m汉\x1b[0m\b\r\n\n")
+}
+
+func TestColumnWriter_split(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := &bytes.Buffer{}
+	w := NewColumnWriter(buf, 4)
+
+	// 拆分的 UTF-8 字符
+	b := []byte("汉")
+	w.Write(b[:1])
+	a.Equal(w.Column(), 0)
+	w.Write(b[1:])
+	a.Equal(w.Column(), 2)
+
+	// 拆分的字符簇
+	w.Write([]byte("❤"))
+	a.Equal(w.Column(), 3)
+	w.Write([]byte("\ufe0f"))
+	a.Equal(w.Column(), 4)
+	w.Write([]byte("e"))
+	w.Write([]byte("\u0301"))
+	a.Equal(w.Column(), 5)
+
+	// 拆分的转义序列
+	w.Write([]byte("\x1b[3"))
+	a.Equal(w.Column(), 5)
+	w.Write([]byte("1mab"))
+	a.Equal(w.Column(), 7)
+	w.Write([]byte("\x1b]8;;https://example.com"))
+	w.Write([]byte("\x1b\\link\x1b]8;;\x1b\\"))
+	a.Equal(w.Column(), 11)
+
+	w.Write([]byte("\t"))
+	a.Equal(w.Column(), 12)
+
+	a.Equal(buf.String(), "汉❤\ufe0fe\u0301\x1b[31mab\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\\t")
+}
+
+func TestColumnWriter_unterminated(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := &bytes.Buffer{}
+	w := NewColumnWriter(buf, 4)
+
+	// 未结束的 OSC 序列
+	w.Write([]byte("\x1b]8;;"))
+	a.Equal(w.Column(), 0)
+	for range maxPendingEscape / 4 {
+		w.Write([]byte("abcd"))
+	}
+	a.True(len(w.pending) < maxPendingEscape).
+		Equal(w.Column(), maxPendingEscape+4) // ]8;; 以及之后的内容作为普通文本
+
+	w.Write([]byte("\n"))
+	a.Equal(w.Column(), 0).Equal(w.Line(), 1).Empty(w.pending)
+}
+
+func TestWidthOptions_NewColumnWriter(t *testing.T) {
+	a := assert.New(t, false)
+
+	wo := DefaultWidthOptions()
+	wo[width.EastAsianWide] = 3
+	w := wo.NewColumnWriter(io.Discard, 8)
+	fmt.Fprint(w, "汉字")
+	a.Equal(w.Column(), 6)
+}
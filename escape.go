@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	esc = 0x1b
+	bel = 0x07
+)
+
+// 返回 s 头部转义序列或是控制字符的字节长度
+//
+// 包括以下内容：
+//   - CSI 序列，比如 \x1b[31m 等颜色设置；
+//   - OSC 序列，以 BEL 或 ST 结尾，包括 OSC 8 超链接；
+//   - DCS、SOS、PM 和 APC 序列，以 ST 结尾；
+//   - 其它以 ESC 开头的转义序列；
+//   - C0 和 C1 控制字符；
+//
+// 如果 s 不是以上述内容开头，返回 0。未结束的序列将一直到 s 的末尾。
+func escapeLen(s string) int {
+	n, _ := parseEscape(s)
+	return n
+}
+
+// 与 escapeLen 相同，但是 complete 表示序列是否已经结束。
+func parseEscape(s string) (n int, complete bool) {
+	if s == "" {
+		return 0, true
+	}
+
+	switch c := s[0]; {
+	case c == esc:
+		if len(s) == 1 {
+			return 1, false
+		}
+		switch s[1] {
+		case '[':
+			n, complete = csiLen(s[2:])
+			return 2 + n, complete
+		case ']':
+			n, complete = stringLen(s[2:], true)
+			return 2 + n, complete
+		case 'P', 'X', '^', '_':
+			n, complete = stringLen(s[2:], false)
+			return 2 + n, complete
+		default: // ESC 中间字符* 结束字符
+			i := 1
+			for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+				i++
+			}
+			if i < len(s) && s[i] >= 0x30 && s[i] <= 0x7e {
+				return i + 1, true
+			}
+			return i, i < len(s)
+		}
+	case c < 0x20 || c == 0x7f:
+		return 1, true
+	case c == 0xc2 && len(s) > 1 && s[1] >= 0x80 && s[1] <= 0x9f: // C1 控制字符的 UTF-8 编码
+		switch s[1] {
+		case 0x9b: // CSI
+			n, complete = csiLen(s[2:])
+			return 2 + n, complete
+		case 0x9d: // OSC
+			n, complete = stringLen(s[2:], true)
+			return 2 + n, complete
+		case 0x90, 0x98, 0x9e, 0x9f: // DCS、SOS、PM、APC
+			n, complete = stringLen(s[2:], false)
+			return 2 + n, complete
+		default:
+			return 2, true
+		}
+	default:
+		return 0, true
+	}
+}
+
+// CSI 参数* 中间字符* 结束字符
+func csiLen(s string) (int, bool) {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1, true
+		}
+		if s[i] < 0x20 || s[i] > 0x3f { // 非法的字符，序列提前结束。
+			return i, true
+		}
+	}
+	return len(s), false
+}
+
+// 以 ST 结尾的字符串，如果 allowBEL 为 true，也可以 BEL 结尾。
+func stringLen(s string, allowBEL bool) (int, bool) {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == bel && allowBEL:
+			return i + 1, true
+		case s[i] == esc && i+1 < len(s) && s[i+1] == '\\':
+			return i + 2, true
+		case s[i] == 0xc2 && i+1 < len(s) && s[i+1] == 0x9c: // U+009C
+			return i + 2, true
+		}
+	}
+	return len(s), false
+}
+
+// StripEscapes 删除 s 中的转义序列和控制字符
+//
+// 转义序列包括 CSI、OSC（包括 OSC 8 超链接）等，控制字符包括 C0 和 C1。
+func StripEscapes(s string) string {
+	b := strings.Builder{}
+	b.Grow(len(s))
+	for s != "" {
+		if n := escapeLen(s); n > 0 {
+			s = s[n:]
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(s)
+		b.WriteString(s[:n])
+		s = s[n:]
+	}
+	return b.String()
+}
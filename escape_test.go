@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestEscapeLen(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []struct {
+		input string
+		n     int
+	}{
+		{input: "", n: 0},
+		{input: "abc", n: 0},
+		{input: "汉字", n: 0},
+		{input: "\x1b[31mabc", n: 5},
+		{input: "\x1b[0;1;38;5;196mabc", n: 15},
+		{input: "\x1b[?25h", n: 6},
+		{input: "\x1b[", n: 2},
+		{input: "\x1b[31", n: 4},
+		{input: "\x1b]0;title\x07abc", n: 10},
+		{input: "\x1b]8;;https://example.com\x1b\\link", n: 26},
+		{input: "\x1b]8;;", n: 5},
+		{input: "\x1bPdata\x1b\\abc", n: 8},
+		{input: "\x1b7abc", n: 2},
+		{input: "\x1b(Babc", n: 3},
+		{input: "\x1b", n: 1},
+		{input: "\tabc", n: 1},
+		{input: "\nabc", n: 1},
+		{input: "\x7fabc", n: 1},
+		{input: "\u009b31mabc", n: 5},
+		{input: "\u009d0;title\u009cabc", n: 11},
+		{input: "\u0085abc", n: 2},
+	}
+
+	for _, item := range data {
+		a.Equal(escapeLen(item.input), item.n, "%q", item.input)
+	}
+}
+
+func TestStripEscapes(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(StripEscapes(""), "").
+		Equal(StripEscapes("abc"), "abc").
+		Equal(StripEscapes("\x1b[31m汉字\x1b[0m"), "汉字").
+		Equal(StripEscapes("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"), "link").
+		Equal(StripEscapes("a\tb\r\n"), "ab")
+}
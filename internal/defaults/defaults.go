@@ -8,9 +8,12 @@
 package defaults
 
 import (
-	"bytes"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+
+	"github.com/issue9/localeutil/apple/plist"
 )
 
 // ReadDomains 从 domain 中查找 key 值
@@ -25,18 +28,81 @@ func ReadDomains(key string, domain ...string) string {
 	return ""
 }
 
+// ReadDomainsArray 从 domain 中查找类型为数组的 key 值
+//
+// 按顺序找到第一个为止。
+func ReadDomainsArray(key string, domain ...string) []string {
+	for _, d := range domain {
+		if l := ReadArray(key, d); len(l) > 0 {
+			return l
+		}
+	}
+	return nil
+}
+
+// ReadArray 读取类型为数组的值
+func ReadArray(key, domain string) []string {
+	return plist.Strings(Value(key, domain))
+}
+
+// Read 读取类型为字符串的值
+//
+// 非字符串类型的值返回空字符串。
 func Read(key, domain string) string {
-	b := &bytes.Buffer{}
-
-	cmd := exec.Command("defaults", "read", domain, key)
-	cmd.Stdout = b
-	if cmd.Run() != nil {
-		// Run() 返回的 err 本身无意思，错误信息存在 cmd.Stderr 或是 cmd.Stdout。
-		// 也无法判断是找不到 key 还是 domain，干脆不作其它处理。
-		return ""
+	v, _ := Value(key, domain).(string)
+	return strings.TrimSpace(v)
+}
+
+// Value 从 domain 中读取 key 的值
+//
+// 直接读取 domain 对应的 plist 文件，而不是调用 defaults 命令。
+// 刚通过 [Write] 写入的值可能还缓存在 cfprefsd 中，需要等其写入文件之后才能读取到。
+// 值的类型可参考 [plist] 包的说明，找不到时返回 nil。
+func Value(key, domain string) any {
+	for _, path := range domainFiles(domain) {
+		v, err := plist.ReadFile(path)
+		if err != nil {
+			// 找不到文件是正常现象，其它错误也无法处理，直接读取下一个文件。
+			continue
+		}
+		if val := plist.Lookup(v, key); val != nil {
+			return val
+		}
+	}
+	return nil
+}
+
+// 返回 domain 对应的 plist 文件
+//
+// domain 可以是以下几种形式：
+//   - -g 或是 NSGlobalDomain，表示 ~/Library/Preferences/.GlobalPreferences.plist；
+//   - 以 / 或是 ~ 开头的路径，可以省略 .plist 扩展名；
+//   - 应用的 ID，比如 com.apple.Safari，
+//     会依次查找 ~/Library/Containers/<id>/Data/Library/Preferences 和 ~/Library/Preferences 下的同名文件；
+func domainFiles(domain string) []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
 	}
 
-	return strings.TrimSpace(b.String())
+	switch {
+	case domain == "-g" || domain == "NSGlobalDomain":
+		return []string{filepath.Join(home, "Library", "Preferences", ".GlobalPreferences.plist")}
+	case strings.HasPrefix(domain, "/") || strings.HasPrefix(domain, "~"):
+		if rest, found := strings.CutPrefix(domain, "~"); found {
+			domain = filepath.Join(home, rest)
+		}
+		if filepath.Ext(domain) != ".plist" {
+			domain += ".plist"
+		}
+		return []string{domain}
+	default:
+		name := domain + ".plist"
+		return []string{
+			filepath.Join(home, "Library", "Containers", domain, "Data", "Library", "Preferences", name),
+			filepath.Join(home, "Library", "Preferences", name),
+		}
+	}
 }
 
 func Write(domain, key, t string, value ...string) error {
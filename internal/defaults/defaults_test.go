@@ -7,7 +7,10 @@
 package defaults
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/issue9/assert/v4"
 )
@@ -20,7 +23,14 @@ func TestReadWrite(t *testing.T) {
 
 	a.NotError(Write(domain, key, "string", "123"))
 
-	v := ReadDomains(key, domain)
+	// 读取的是 plist 文件，需要等待 cfprefsd 将数据写入文件。
+	var v string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if v = ReadDomains(key, domain); v == "123" {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
 	a.Equal(v, "123")
 
 	v = ReadDomains("not-exists", domain)
@@ -29,3 +39,18 @@ func TestReadWrite(t *testing.T) {
 	v = ReadDomains(key, "not-exists")
 	a.Equal(v, "")
 }
+
+func TestDomainFiles(t *testing.T) {
+	a := assert.New(t, false)
+
+	home, err := os.UserHomeDir()
+	a.NotError(err)
+
+	a.Equal(domainFiles("-g"), []string{filepath.Join(home, "Library/Preferences/.GlobalPreferences.plist")}).
+		Equal(domainFiles("~/Library/Preferences/.GlobalPreferences"), []string{filepath.Join(home, "Library/Preferences/.GlobalPreferences.plist")}).
+		Equal(domainFiles("/Library/Preferences/x.plist"), []string{"/Library/Preferences/x.plist"}).
+		Equal(domainFiles("com.example.test"), []string{
+			filepath.Join(home, "Library/Containers/com.example.test/Data/Library/Preferences/com.example.test.plist"),
+			filepath.Join(home, "Library/Preferences/com.example.test.plist"),
+		})
+}
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+// Package grapheme 按 UAX #29 拆分扩展字符簇
+//
+// https://www.unicode.org/reports/tr29/#Grapheme_Cluster_Boundaries
+package grapheme
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Property 字符的 Grapheme_Cluster_Break 属性
+type Property uint8
+
+const (
+	Other Property = iota
+	CR
+	LF
+	Control
+	Extend
+	ZWJ
+	RegionalIndicator
+	Prepend
+	SpacingMark
+	L
+	V
+	T
+	LV
+	LVT
+	ExtendedPictographic
+)
+
+const (
+	zwj = '\u200d'
+
+	// VS15 以文本形式显示前一个字符
+	VS15 = '\ufe0e'
+
+	// VS16 以 emoji 形式显示前一个字符
+	VS16 = '\ufe0f'
+)
+
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x0605, Stride: 1},
+		{Lo: 0x06dd, Hi: 0x06dd, Stride: 1},
+		{Lo: 0x070f, Hi: 0x070f, Stride: 1},
+		{Lo: 0x0890, Hi: 0x0891, Stride: 1},
+		{Lo: 0x08e2, Hi: 0x08e2, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x110bd, Hi: 0x110bd, Stride: 1},
+		{Lo: 0x110cd, Hi: 0x110cd, Stride: 1},
+	},
+}
+
+// Other_Grapheme_Extend 以及 emoji 修饰符等不属于 Mn 和 Me 的 Extend 字符
+var extend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x09be, Hi: 0x09be, Stride: 1},
+		{Lo: 0x09d7, Hi: 0x09d7, Stride: 1},
+		{Lo: 0x0b3e, Hi: 0x0b3e, Stride: 1},
+		{Lo: 0x0b57, Hi: 0x0b57, Stride: 1},
+		{Lo: 0x0bbe, Hi: 0x0bbe, Stride: 1},
+		{Lo: 0x0bd7, Hi: 0x0bd7, Stride: 1},
+		{Lo: 0x0cc2, Hi: 0x0cc2, Stride: 1},
+		{Lo: 0x0cd5, Hi: 0x0cd6, Stride: 1},
+		{Lo: 0x0d3e, Hi: 0x0d3e, Stride: 1},
+		{Lo: 0x0d57, Hi: 0x0d57, Stride: 1},
+		{Lo: 0x0dcf, Hi: 0x0dcf, Stride: 1},
+		{Lo: 0x0ddf, Hi: 0x0ddf, Stride: 1},
+		{Lo: 0x200c, Hi: 0x200c, Stride: 1},
+		{Lo: 0x302e, Hi: 0x302f, Stride: 1},
+		{Lo: 0xff9e, Hi: 0xff9f, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f3fb, Hi: 0x1f3ff, Stride: 1},
+		{Lo: 0xe0020, Hi: 0xe007f, Stride: 1},
+	},
+}
+
+// Extended_Pictographic
+var pictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a9, Hi: 0x00a9, Stride: 1},
+		{Lo: 0x00ae, Hi: 0x00ae, Stride: 1},
+		{Lo: 0x203c, Hi: 0x203c, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21a9, Hi: 0x21aa, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x2388, Hi: 0x2388, Stride: 1},
+		{Lo: 0x23cf, Hi: 0x23cf, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x23f8, Hi: 0x23fa, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25ab, Stride: 1},
+		{Lo: 0x25b6, Hi: 0x25b6, Stride: 1},
+		{Lo: 0x25c0, Hi: 0x25c0, Stride: 1},
+		{Lo: 0x25fb, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x2605, Stride: 1},
+		{Lo: 0x2607, Hi: 0x2612, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2685, Stride: 1},
+		{Lo: 0x2690, Hi: 0x2705, Stride: 1},
+		{Lo: 0x2708, Hi: 0x2712, Stride: 1},
+		{Lo: 0x2714, Hi: 0x2714, Stride: 1},
+		{Lo: 0x2716, Hi: 0x2716, Stride: 1},
+		{Lo: 0x271d, Hi: 0x271d, Stride: 1},
+		{Lo: 0x2721, Hi: 0x2721, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x2733, Hi: 0x2734, Stride: 1},
+		{Lo: 0x2744, Hi: 0x2744, Stride: 1},
+		{Lo: 0x2747, Hi: 0x2747, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2763, Hi: 0x2767, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27a1, Hi: 0x27a1, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b07, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303d, Hi: 0x303d, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f0ff, Stride: 1},
+		{Lo: 0x1f10d, Hi: 0x1f10f, Stride: 1},
+		{Lo: 0x1f12f, Hi: 0x1f12f, Stride: 1},
+		{Lo: 0x1f16c, Hi: 0x1f171, Stride: 1},
+		{Lo: 0x1f17e, Hi: 0x1f17f, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f1ad, Hi: 0x1f1e5, Stride: 1},
+		{Lo: 0x1f201, Hi: 0x1f20f, Stride: 1},
+		{Lo: 0x1f21a, Hi: 0x1f21a, Stride: 1},
+		{Lo: 0x1f22f, Hi: 0x1f22f, Stride: 1},
+		{Lo: 0x1f232, Hi: 0x1f23a, Stride: 1},
+		{Lo: 0x1f23c, Hi: 0x1f23f, Stride: 1},
+		{Lo: 0x1f249, Hi: 0x1f3fa, Stride: 1},
+		{Lo: 0x1f400, Hi: 0x1f53d, Stride: 1},
+		{Lo: 0x1f546, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f774, Hi: 0x1f77f, Stride: 1},
+		{Lo: 0x1f7d5, Hi: 0x1f7ff, Stride: 1},
+		{Lo: 0x1f80c, Hi: 0x1f80f, Stride: 1},
+		{Lo: 0x1f848, Hi: 0x1f84f, Stride: 1},
+		{Lo: 0x1f85a, Hi: 0x1f85f, Stride: 1},
+		{Lo: 0x1f888, Hi: 0x1f88f, Stride: 1},
+		{Lo: 0x1f8ae, Hi: 0x1f8ff, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f93a, Stride: 1},
+		{Lo: 0x1f93c, Hi: 0x1f945, Stride: 1},
+		{Lo: 0x1f947, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x1fc00, Hi: 0x1fffd, Stride: 1},
+	},
+}
+
+// Lookup 返回 r 的 Grapheme_Cluster_Break 属性
+func Lookup(r rune) Property {
+	switch {
+	case r == '\r':
+		return CR
+	case r == '\n':
+		return LF
+	case r == zwj:
+		return ZWJ
+	case r < 0x7f && r >= 0x20: // ASCII 中的可打印字符
+		return Other
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return RegionalIndicator
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return LV
+		}
+		return LVT
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return L
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return V
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return T
+	case unicode.Is(prepend, r):
+		return Prepend
+	case unicode.In(r, unicode.Mn, unicode.Me, extend):
+		return Extend
+	case unicode.In(r, unicode.Cc, unicode.Zl, unicode.Zp, unicode.Cf):
+		return Control
+	case unicode.Is(unicode.Mc, r):
+		return SpacingMark
+	case unicode.Is(pictographic, r):
+		return ExtendedPictographic
+	default:
+		return Other
+	}
+}
+
+// Breaker 依次判断字符之间是否存在边界
+//
+// 零值即可直接使用。
+type Breaker struct {
+	prev    Property
+	started bool
+	ri      int  // 当前连续的 RegionalIndicator 数量
+	pict    bool // 是否处于 ExtendedPictographic Extend* 之后
+	pictZWJ bool // 是否处于 ExtendedPictographic Extend* ZWJ 之后
+}
+
+// Next 添加字符 r 并返回 r 之前是否为字符簇的边界
+//
+// 第一个字符之前永远是边界。
+func (b *Breaker) Next(r rune) bool { return b.NextProperty(Lookup(r)) }
+
+// NextProperty 添加属性为 p 的字符并返回该字符之前是否为字符簇的边界
+func (b *Breaker) NextProperty(p Property) bool {
+	if p == Other && b.prev == Other && b.started { // 最常见的情况
+		b.pict, b.pictZWJ, b.ri = false, false, 0
+		return true
+	}
+
+	boundary := !b.started || b.isBoundary(p)
+	b.started = true
+
+	b.pictZWJ = b.pict && p == ZWJ
+	switch p {
+	case ExtendedPictographic:
+		b.pict = true
+	case Extend:
+	default:
+		b.pict = false
+	}
+
+	if p == RegionalIndicator {
+		b.ri++
+	} else {
+		b.ri = 0
+	}
+
+	b.prev = p
+	return boundary
+}
+
+func (b *Breaker) isBoundary(p Property) bool {
+	prev := b.prev
+	switch {
+	case prev == CR && p == LF: // GB3
+		return false
+	case prev == CR || prev == LF || prev == Control: // GB4
+		return true
+	case p == CR || p == LF || p == Control: // GB5
+		return true
+	case prev == L && (p == L || p == V || p == LV || p == LVT): // GB6
+		return false
+	case (prev == LV || prev == V) && (p == V || p == T): // GB7
+		return false
+	case (prev == LVT || prev == T) && p == T: // GB8
+		return false
+	case p == Extend || p == ZWJ: // GB9
+		return false
+	case p == SpacingMark: // GB9a
+		return false
+	case prev == Prepend: // GB9b
+		return false
+	case b.pictZWJ && p == ExtendedPictographic: // GB11
+		return false
+	case prev == RegionalIndicator && p == RegionalIndicator: // GB12, GB13
+		return b.ri%2 == 0
+	default: // GB999
+		return true
+	}
+}
+
+// Reset 重置状态
+func (b *Breaker) Reset() { *b = Breaker{} }
+
+// First 返回 s 中第一个字符簇的字节长度
+func First(s string) int {
+	var b Breaker
+	for i, r := range s {
+		if b.Next(r) && i > 0 {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// Split 将 s 拆分为字符簇
+func Split(s string) []string {
+	clusters := make([]string, 0, utf8.RuneCountInString(s))
+	for s != "" {
+		n := First(s)
+		clusters = append(clusters, s[:n])
+		s = s[n:]
+	}
+	return clusters
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package grapheme
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestLookup(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Lookup('a'), Other).
+		Equal(Lookup('\r'), CR).
+		Equal(Lookup('\n'), LF).
+		Equal(Lookup('\t'), Control).
+		Equal(Lookup('\u0301'), Extend).
+		Equal(Lookup(VS16), Extend).
+		Equal(Lookup('\U0001F3FB'), Extend).
+		Equal(Lookup('\u200d'), ZWJ).
+		Equal(Lookup('\U0001F1E8'), RegionalIndicator).
+		Equal(Lookup('\u0600'), Prepend).
+		Equal(Lookup('\u0903'), SpacingMark).
+		Equal(Lookup('ᄀ'), L).
+		Equal(Lookup('ᅡ'), V).
+		Equal(Lookup('ᆨ'), T).
+		Equal(Lookup('가'), LV).
+		Equal(Lookup('각'), LVT).
+		Equal(Lookup('😀'), ExtendedPictographic).
+		Equal(Lookup('❤'), ExtendedPictographic).
+		Equal(Lookup('汉'), Other)
+}
+
+func TestSplit(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Empty(Split("")).
+		Equal(Split("abc"), []string{"a", "b", "c"}).
+		Equal(Split("汉字"), []string{"汉", "字"}).
+		Equal(Split("a\r\nb"), []string{"a", "\r\n", "b"}).
+		Equal(Split("\n\r"), []string{"\n", "\r"}).
+		Equal(Split("e\u0301x"), []string{"e\u0301", "x"}).                 // 组合字符
+		Equal(Split("\u0301x"), []string{"\u0301", "x"}).                   // 孤立的组合字符
+		Equal(Split("❤\ufe0f!"), []string{"❤\ufe0f", "!"}).                 // VS16
+		Equal(Split("👍🏽👍"), []string{"👍🏽", "👍"}).                           // 肤色
+		Equal(Split("👨\u200d👩\u200d👧a"), []string{"👨\u200d👩\u200d👧", "a"}). // ZWJ
+		Equal(Split("a\u200d😀"), []string{"a\u200d", "😀"}).                 // GB11 只作用于 emoji
+		Equal(Split("🇨🇳🇺🇸🇯"), []string{"🇨🇳", "🇺🇸", "🇯"}).                   // 国旗
+		Equal(Split("각가"), []string{"각", "가"}).
+		Equal(Split("\u0600a"), []string{"\u0600a"}).
+		Equal(Split("क\u0903"), []string{"क\u0903"}).
+		Equal(Split("a\tb"), []string{"a", "\t", "b"})
+}
+
+func TestFirst(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(First(""), 0).
+		Equal(First("a"), 1).
+		Equal(First("e\u0301x"), 3).
+		Equal(First("🇨🇳🇺🇸"), 8)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ICU 关键字与 BCP 47 中 -u- 扩展键名的对应关系
+var appleKeywords = map[string]string{
+	"calendar":     "ca",
+	"collation":    "co",
+	"currency":     "cu",
+	"hours":        "hc",
+	"measure":      "ms",
+	"numbers":      "nu",
+	"colcasefirst": "kf",
+	"colnumeric":   "kn",
+	"fw":           "fw",
+	"rg":           "rg",
+	"sd":           "sd",
+}
+
+// ICU 关键字的值与 BCP 47 中值不相同的部分
+var appleKeywordValues = map[string]map[string]string{
+	"ca": {
+		"gregorian":           "gregory",
+		"ethiopic-amete-alem": "ethioaa",
+	},
+	"co": {
+		"dictionary":  "dict",
+		"gb2312han":   "gb2312",
+		"phonebook":   "phonebk",
+		"traditional": "trad",
+	},
+	"ms": {
+		"imperial": "uksystem",
+	},
+}
+
+// ParseApple 将苹果系统中的本地化 ID 转换为 [language.Tag]
+//
+// 苹果系统采用 ICU 格式的 ID，比如：
+//   - zh-Hans-CN、zh_CN，与 BCP 47 的区别仅在于可能采用 _ 作为分隔符；
+//   - en_US@rg=gbzzzz，rg 表示区域设置，转换为 en-US-u-rg-gbzzzz；
+//   - zh_CN@calendar=chinese;numbers=hanidec，转换为 zh-CN-u-ca-chinese-nu-hanidec；
+//
+// 无法识别的关键字会被忽略。
+func ParseApple(id string) (language.Tag, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return language.Und, errEmptyLocale
+	}
+
+	id, keywords, _ := strings.Cut(id, "@")
+	b := &strings.Builder{}
+	b.WriteString(strings.ReplaceAll(id, "_", "-"))
+
+	ext := make([]string, 0, 4)
+	for _, kw := range strings.Split(keywords, ";") {
+		key, val, found := strings.Cut(kw, "=")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.ToLower(strings.TrimSpace(val))
+
+		k, found := appleKeywords[key]
+		if !found || val == "" {
+			continue
+		}
+		if v, found := appleKeywordValues[k][val]; found {
+			val = v
+		}
+		ext = append(ext, k, val)
+	}
+
+	if len(ext) > 0 {
+		b.WriteString("-u-")
+		b.WriteString(strings.Join(ext, "-"))
+	}
+
+	return language.Parse(b.String())
+}
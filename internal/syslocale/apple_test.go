@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func TestParseApple(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []*struct {
+		id  string
+		tag string
+	}{
+		{id: "zh-Hans-CN", tag: "zh-Hans-CN"},
+		{id: "zh_CN", tag: "zh-CN"},
+		{id: "zh-Hans_CN", tag: "zh-Hans-CN"},
+		{id: "en_US@rg=gbzzzz", tag: "en-US-u-rg-gbzzzz"},
+		{id: "en_US@rg=GBZZZZ", tag: "en-US-u-rg-gbzzzz"},
+		{id: "zh_CN@calendar=chinese", tag: "zh-CN-u-ca-chinese"},
+		{id: "zh_CN@calendar=chinese;numbers=hanidec", tag: "zh-CN-u-ca-chinese-nu-hanidec"},
+		{id: "ja_JP@calendar=gregorian", tag: "ja-JP-u-ca-gregory"},
+		{id: "de_DE@collation=phonebook", tag: "de-DE-u-co-phonebk"},
+		{id: "en_GB@measure=imperial; hours=h23", tag: "en-GB-u-ms-uksystem-hc-h23"},
+		{id: "fr_FR@unknown=value;rg", tag: "fr-FR"},
+		{id: " en ", tag: "en"},
+	}
+	for _, item := range data {
+		tag, err := ParseApple(item.id)
+		a.NotError(err, item.id).Equal(tag, language.MustParse(item.tag), item.id)
+	}
+
+	tag, err := ParseApple("")
+	a.Equal(err, errEmptyLocale).Equal(tag, language.Und)
+
+	_, err = ParseApple("zh_CN_中文@rg=cnzzzz")
+	a.Error(err)
+}
+
+func TestTrimLocale_apple(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(trimLocale("en_US@rg=gbzzzz"), "en-US-u-rg-gbzzzz").
+		Equal(trimLocale("sr_RS@latin"), "sr-Latn-RS").
+		Equal(trimLocale("zh_CN_中文@rg=cnzzzz"), "zh_CN_中文@rg=cnzzzz")
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+// Category 本地化的分类
+type Category int8
+
+const (
+	Messages Category = iota // LC_MESSAGES
+	Time                     // LC_TIME
+	Numeric                  // LC_NUMERIC
+	Monetary                 // LC_MONETARY
+	Collate                  // LC_COLLATE
+	CType                    // LC_CTYPE
+)
+
+var categoryEnvs = map[Category]string{
+	Messages: "LC_MESSAGES",
+	Time:     "LC_TIME",
+	Numeric:  "LC_NUMERIC",
+	Monetary: "LC_MONETARY",
+	Collate:  "LC_COLLATE",
+	CType:    "LC_CTYPE",
+}
+
+// String 返回对应的环境变量名称
+func (c Category) String() string {
+	if env, found := categoryEnvs[c]; found {
+		return env
+	}
+	return "<unknown>"
+}
+
+// 按优先级返回 c 相关的变量名
+func (c Category) envs() []string { return []string{"LC_ALL", c.String(), "LANG"} }
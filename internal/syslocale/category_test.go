@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestCategory_String(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Messages.String(), "LC_MESSAGES").
+		Equal(CType.String(), "LC_CTYPE").
+		Equal(Category(100).String(), "<unknown>")
+}
+
+func TestGetCategory(t *testing.T) {
+	a := assert.New(t, false)
+
+	for _, c := range []Category{Messages, Time, Numeric, Monetary, Collate, CType} {
+		t.Setenv(c.String(), "")
+	}
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_TIME", "de_DE.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	a.Equal(GetCategory(Time), "de-DE").
+		Equal(GetCategory(Numeric), "en-US").
+		Equal(GetCategory(Messages), "en-US")
+
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+	a.Equal(GetCategory(Time), "fr-FR").
+		Equal(GetCategory(Numeric), "fr-FR")
+}
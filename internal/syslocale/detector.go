@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Source 本地化信息的来源
+type Source int8
+
+const (
+	SourceLanguage Source = iota // 环境变量 LANGUAGE
+	SourceOS                     // 平台相关的接口，比如 windows 下的 GetUserDefaultLocaleName，始终读取当前系统的设置
+	SourceFiles                  // 配置文件，比如 linux 下的 locale.conf
+	SourceEnv                    // 环境变量 LC_ALL、LC_MESSAGES 和 LANG
+)
+
+var envs = [...]string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+var sourceNames = map[Source]string{
+	SourceLanguage: "language",
+	SourceOS:       "os",
+	SourceFiles:    "files",
+	SourceEnv:      "env",
+}
+
+// String 返回来源的名称
+func (s Source) String() string {
+	if name, found := sourceNames[s]; found {
+		return name
+	}
+	return "<unknown>"
+}
+
+// DefaultSources 当前平台默认的读取顺序
+//
+// 返回值是一个副本，可以修改之后赋值给 [Detector.Sources]。
+func DefaultSources() []Source { return slices.Clone(defaultSources) }
+
+// Detector 本地化信息的检测
+//
+// 零值表示检测当前系统的本地化信息，
+// 也可以通过指定各个字段用于测试或是检测远程会话的本地化信息。
+//
+// NOTE: [SourceOS] 调用的是当前系统的接口，比如 macOS 的偏好设置和 windows 的 API，
+// 不受 Getenv 和 FS 的影响。检测远程会话或是容器中的本地化信息时，
+// 应该从 Sources 中去掉 [SourceOS]，否则可能返回当前系统的设置。
+type Detector struct {
+	// 读取环境变量的方法
+	//
+	// 为空表示 [os.Getenv]。
+	Getenv func(string) string
+
+	// 读取配置文件的文件系统
+	//
+	// 以系统的根目录作为 FS 的根目录，比如 /etc/locale.conf 对应 FS 中的 etc/locale.conf。
+	// 为空表示 os.DirFS("/")。
+	FS fs.FS
+
+	// 按优先级排列的读取来源
+	//
+	// 为空表示 [DefaultSources] 的返回值，不在此列表中的来源不会被读取。
+	Sources []Source
+}
+
+func (d *Detector) getenv(key string) string {
+	if d.Getenv == nil {
+		return os.Getenv(key)
+	}
+	return d.Getenv(key)
+}
+
+func (d *Detector) fsys() fs.FS {
+	if d.FS == nil {
+		return os.DirFS("/")
+	}
+	return d.FS
+}
+
+func (d *Detector) sources() []Source {
+	if len(d.Sources) == 0 {
+		return defaultSources
+	}
+	return d.Sources
+}
+
+// 某一来源中的值
+type candidate struct {
+	src   Source
+	from  string // 出处，可以是环境变量名、文件路径或是平台相关的接口名称
+	raw   string // 原始值
+	value string // 转换为 BCP 47 格式之后的值
+}
+
+// Get 返回优先级最高的本地化信息
+//
+// 返回值为 BCP 47 格式，比如 zh_CN.UTF-8 会被转换为 zh-CN。
+// C、POSIX 以及 C.UTF-8 等值不表示具体的语言，会被跳过。
+func (d *Detector) Get() string {
+	lang, _ := d.GetFrom()
+	return lang
+}
+
+// GetFrom 返回优先级最高的本地化信息及其出处
+//
+// lang 与 [Detector.Get] 相同；
+// from 表示值的出处，可以是环境变量名(比如 LC_ALL)、配置文件的路径(比如 /etc/locale.conf)
+// 或是平台相关的接口名称(比如 GetUserDefaultLocaleName)。
+func (d *Detector) GetFrom() (lang, from string) {
+	for _, src := range d.sources() {
+		if cs := d.read(src, true); len(cs) > 0 {
+			return cs[0].value, cs[0].from
+		}
+	}
+	return "", ""
+}
+
+// GetAll 按优先级返回所有来源中的本地化信息
+//
+// 顺序与 [Detector.Get] 相同，但是会返回所有来源中的值：
+// 环境变量 LANGUAGE 可以是以冒号分隔的多个值，平台相关的设置也可能是多个值。
+// 返回值中可能包含重复的内容。
+func (d *Detector) GetAll() []string {
+	var langs []string
+	for _, src := range d.sources() {
+		for _, c := range d.read(src, false) {
+			langs = append(langs, c.value)
+		}
+	}
+	return langs
+}
+
+// Result 检测结果及其出处
+type Result struct {
+	Value  string       // 原始值，比如 zh_CN.UTF-8
+	Tag    language.Tag // Value 转换后的值，无法解析时为 [language.Und]
+	Source Source       // 来源
+
+	// 值的出处
+	//
+	// 可以是环境变量名(比如 LC_ALL)、配置文件的路径(比如 /etc/locale.conf)
+	// 或是平台相关的接口名称(比如 GetUserDefaultLocaleName)。
+	From string
+
+	// 优先级较低而被忽略的值
+	//
+	// 按优先级排列，仅由 [Detector.Detect] 返回的对象包含此值。
+	Shadowed []*Result
+}
+
+// Detect 返回优先级最高的本地化信息及其出处
+//
+// 同时也会返回所有被忽略的低优先级值，可用于调试本地化信息的来源。
+// 如果没有任何值，返回 nil。
+func (d *Detector) Detect() *Result {
+	var rs []*Result
+	for _, src := range d.sources() {
+		for _, c := range d.read(src, false) {
+			rs = append(rs, c.result())
+		}
+	}
+
+	if len(rs) == 0 {
+		return nil
+	}
+	rs[0].Shadowed = rs[1:]
+	return rs[0]
+}
+
+func (c *candidate) result() *Result {
+	tag, err := language.Parse(c.value)
+	if err != nil {
+		tag = language.Und
+	}
+	return &Result{
+		Value:  strings.TrimSpace(c.raw),
+		Tag:    tag,
+		Source: c.src,
+		From:   c.from,
+	}
+}
+
+// String 输出检测结果
+//
+// 格式如下：
+//
+//	zh-CN from LANGUAGE (zh_CN.UTF-8)
+//	shadowed:
+//	  en from LANGUAGE (en)
+//	  ja-JP from LANG (ja_JP.UTF-8)
+func (r *Result) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%s from %s (%s)\n", r.Tag, r.From, r.Value)
+
+	if len(r.Shadowed) > 0 {
+		b.WriteString("shadowed:\n")
+		for _, s := range r.Shadowed {
+			fmt.Fprintf(b, "  %s from %s (%s)\n", s.Tag, s.From, s.Value)
+		}
+	}
+
+	return b.String()
+}
+
+// GetCategory 返回分类 c 的本地化信息
+//
+// 按 POSIX 的规则，如果 [Detector.Sources] 中包含 [SourceEnv]，那么无论其位置如何，
+// LC_ALL、LC_<category> 和 LANG 环境变量总是最先读取；
+// 之后才按 [Detector.Sources] 的顺序读取其它来源，[SourceLanguage] 会被忽略。
+// 与 [Detector.Get] 一样，不在 [Detector.Sources] 中的来源不会被读取。
+//
+// 与 [Detector.Get] 相同，C、POSIX 等值会被跳过。
+func (d *Detector) GetCategory(c Category) string { return d.getCategory(c, trimLocale) }
+
+// Codeset 返回 [CType] 分类中的编码名称
+//
+// 比如 zh_CN.GB18030 返回 GB18030，值未指定编码或是平台不采用 POSIX 格式的值时返回空字符串。
+// 返回值为原始值，可能存在大小写等方面的差异，比如 UTF-8 和 utf8。
+func (d *Detector) Codeset() string {
+	// LC_CTYPE=C 表示 ASCII 编码，不能跳过 C 而读取优先级较低的值。
+	if l, err := Parse(d.getCategory(CType, strings.TrimSpace)); err == nil {
+		return l.Codeset
+	}
+	return ""
+}
+
+// 返回分类 c 中第一个经 conv 转换之后不为空的值
+//
+// 读取顺序参考 [Detector.GetCategory]。
+func (d *Detector) getCategory(c Category, conv func(string) string) string {
+	srcs := d.sources()
+
+	if slices.Contains(srcs, SourceEnv) {
+		for _, env := range c.envs() {
+			if v := conv(d.getenv(env)); v != "" {
+				return v
+			}
+		}
+	}
+
+	for _, src := range srcs {
+		switch src {
+		case SourceOS:
+			if v := conv(getOSCategory(c)); v != "" {
+				return v
+			}
+		case SourceFiles:
+			for _, f := range d.readFiles() {
+				if v := conv(lookup(f.vars, c.envs()...)); v != "" {
+					return v
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// Tag 返回 [Detector.Get] 对应的 [language.Tag]
+func (d *Detector) Tag() (language.Tag, error) { return language.Parse(d.Get()) }
+
+// CategoryTag 返回 [Detector.GetCategory] 对应的 [language.Tag]
+func (d *Detector) CategoryTag(c Category) (language.Tag, error) {
+	return language.Parse(d.GetCategory(c))
+}
+
+// Tags 返回 [Detector.GetAll] 对应的 [language.Tag]
+//
+// 返回值已经去重，且忽略了无法解析的值以及 [language.Und]。
+func (d *Detector) Tags() []language.Tag {
+	langs := d.GetAll()
+	tags := make([]language.Tag, 0, len(langs))
+	for _, lang := range langs {
+		tag, err := language.Parse(lang)
+		if err != nil || tag == language.Und || slices.Contains(tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// 读取 src 中的本地化信息
+//
+// first 表示只需要第一个值。
+func (d *Detector) read(src Source, first bool) []*candidate {
+	var cs []*candidate
+	add := func(c *candidate) bool {
+		if c.value = trimLocale(c.raw); c.value != "" {
+			c.src = src
+			cs = append(cs, c)
+		}
+		return first && len(cs) > 0
+	}
+
+	switch src {
+	case SourceLanguage:
+		// LANGUAGE 是以冒号分隔的列表，比如 zh_CN:zh_TW:en。
+		for _, v := range strings.Split(d.getenv("LANGUAGE"), ":") {
+			if add(&candidate{from: "LANGUAGE", raw: v}) {
+				break
+			}
+		}
+	case SourceOS:
+		for _, c := range getOSLocaleNames() {
+			if add(c) {
+				break
+			}
+		}
+	case SourceFiles:
+		for _, f := range d.readFiles() {
+			if add(&candidate{from: f.path, raw: lookup(f.vars, envs[:]...)}) {
+				break
+			}
+		}
+	case SourceEnv:
+		for _, env := range envs {
+			if add(&candidate{from: env, raw: d.getenv(env)}) {
+				break
+			}
+		}
+	}
+
+	return cs
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func newDetector(env map[string]string, files map[string]string, srcs ...Source) *Detector {
+	fsys := fstest.MapFS{}
+	for p, data := range files {
+		fsys[p] = &fstest.MapFile{Data: []byte(data)}
+	}
+
+	return &Detector{
+		Getenv:  func(key string) string { return env[key] },
+		FS:      fsys,
+		Sources: srcs,
+	}
+}
+
+func TestSource_String(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(SourceLanguage.String(), "language").
+		Equal(SourceFiles.String(), "files").
+		Equal(Source(100).String(), "<unknown>")
+}
+
+func TestDefaultSources(t *testing.T) {
+	a := assert.New(t, false)
+
+	srcs := DefaultSources()
+	a.NotEmpty(srcs).Equal(srcs[0], SourceLanguage)
+
+	srcs[0] = SourceEnv
+	a.Equal(DefaultSources()[0], SourceLanguage)
+}
+
+func TestDetector_Get(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := map[string]string{
+		"HOME":     "/home/user",
+		"LANGUAGE": "zh_CN.UTF-8:zh_TW::en",
+		"LC_ALL":   "",
+		"LANG":     "ja_JP.UTF-8",
+	}
+	files := map[string]string{
+		"home/user/.config/locale.conf": "LANG=fr_FR.UTF-8\n",
+		"etc/locale.conf":               "LANG=\"de_DE.UTF-8\"\nLC_TIME=en_GB.UTF-8\n",
+	}
+
+	d := newDetector(env, files, SourceLanguage, SourceFiles, SourceEnv)
+	a.Equal(d.Get(), "zh-CN").
+		Equal(d.GetAll(), []string{"zh-CN", "zh-TW", "en", "fr-FR", "de-DE", "ja-JP"})
+
+	tag, err := d.Tag()
+	a.NotError(err).Equal(tag, language.MustParse("zh-CN"))
+
+	lang, from := d.GetFrom()
+	a.Equal(lang, "zh-CN").Equal(from, "LANGUAGE")
+
+	// 调整顺序
+	d = newDetector(env, files, SourceEnv, SourceFiles)
+	a.Equal(d.Get(), "ja-JP").
+		Equal(d.GetAll(), []string{"ja-JP", "fr-FR", "de-DE"})
+
+	d = newDetector(env, files, SourceFiles, SourceEnv)
+	lang, from = d.GetFrom()
+	a.Equal(lang, "fr-FR").Equal(from, "/home/user/.config/locale.conf")
+
+	// XDG_CONFIG_HOME
+	env["XDG_CONFIG_HOME"] = "/xdg"
+	files["xdg/locale.conf"] = "LANG=ko_KR.UTF-8"
+	d = newDetector(env, files, SourceFiles)
+	a.Equal(d.GetAll(), []string{"ko-KR", "de-DE"})
+
+	// 空
+	d = newDetector(nil, nil, SourceLanguage, SourceFiles, SourceEnv)
+	a.Empty(d.Get()).Empty(d.GetAll())
+	lang, from = d.GetFrom()
+	a.Empty(lang).Empty(from)
+	_, err = d.Tag()
+	a.Error(err)
+}
+
+func TestDetector_Tags(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := map[string]string{
+		"LANGUAGE": "zh_CN:zh-Hans-CN:en:C",
+		"LC_ALL":   "zh_CN.UTF-8",
+		"LANG":     "en_US.UTF-8",
+	}
+	d := newDetector(env, nil, SourceLanguage, SourceEnv)
+	a.Equal(d.Tags(), []language.Tag{
+		language.MustParse("zh-CN"),
+		language.MustParse("zh-Hans-CN"),
+		language.English,
+		language.AmericanEnglish,
+	})
+}
+
+func TestDetector_GetCategory(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := map[string]string{
+		"LANGUAGE":    "zh_CN",
+		"LC_MONETARY": "ja_JP.UTF-8",
+	}
+	files := map[string]string{
+		"etc/locale.conf": "LANG=de_DE.UTF-8\nLC_TIME=en_GB.UTF-8\n",
+	}
+
+	// 环境变量优先于配置文件，且忽略 LANGUAGE
+	d := newDetector(env, files, SourceLanguage, SourceFiles, SourceEnv)
+	a.Equal(d.GetCategory(Monetary), "ja-JP").
+		Equal(d.GetCategory(Time), "en-GB").
+		Equal(d.GetCategory(Messages), "de-DE")
+
+	tag, err := d.CategoryTag(Time)
+	a.NotError(err).Equal(tag, language.MustParse("en-GB"))
+
+	// 不包含 SourceEnv
+	d = newDetector(env, files, SourceFiles)
+	a.Equal(d.GetCategory(Monetary), "de-DE")
+
+	d = newDetector(env, nil, SourceLanguage)
+	a.Empty(d.GetCategory(Messages))
+}
+
+func TestDetector_Detect(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := map[string]string{
+		"HOME":     "/home/user",
+		"LANGUAGE": "zh_CN.UTF-8:en",
+		"LANG":     "ja_JP.UTF-8",
+		"LC_ALL":   "zh_CN_中文",
+	}
+	files := map[string]string{
+		"etc/locale.conf": "LANG=de_DE.UTF-8\n",
+	}
+
+	d := newDetector(env, files, SourceLanguage, SourceFiles, SourceEnv)
+	r := d.Detect()
+	a.NotNil(r).
+		Equal(r.Value, "zh_CN.UTF-8").
+		Equal(r.Tag, language.MustParse("zh-CN")).
+		Equal(r.Source, SourceLanguage).
+		Equal(r.From, "LANGUAGE").
+		Length(r.Shadowed, 4)
+
+	s := r.Shadowed[1]
+	a.Equal(s.Value, "de_DE.UTF-8").
+		Equal(s.Tag, language.MustParse("de-DE")).
+		Equal(s.Source, SourceFiles).
+		Equal(s.From, "/etc/locale.conf").
+		Empty(s.Shadowed)
+
+	s = r.Shadowed[2] // 无法解析
+	a.Equal(s.Value, "zh_CN_中文").
+		Equal(s.Tag, language.Und).
+		Equal(s.From, "LC_ALL")
+
+	a.Equal(r.String(), `zh-CN from LANGUAGE (zh_CN.UTF-8)
+shadowed:
+  en from LANGUAGE (en)
+  de-DE from /etc/locale.conf (de_DE.UTF-8)
+  und from LC_ALL (zh_CN_中文)
+  ja-JP from LANG (ja_JP.UTF-8)
+`)
+
+	// 只有一个值
+	d = newDetector(env, files, SourceFiles)
+	r = d.Detect()
+	a.NotNil(r).Empty(r.Shadowed).
+		Equal(r.String(), "de-DE from /etc/locale.conf (de_DE.UTF-8)\n")
+
+	d = newDetector(nil, nil, SourceLanguage, SourceFiles, SourceEnv)
+	a.Nil(d.Detect())
+}
+
+func TestDetector_skipPOSIX(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := map[string]string{
+		"LANGUAGE": "C",
+		"LC_ALL":   "C.UTF-8",
+		"LANG":     "POSIX",
+	}
+	files := map[string]string{"etc/locale.conf": "LANG=zh_CN.UTF-8"}
+	d := newDetector(env, files, SourceLanguage, SourceEnv, SourceFiles)
+	a.Equal(d.Get(), "zh-CN").
+		Equal(d.GetAll(), []string{"zh-CN"}).
+		Equal(d.GetCategory(Messages), "zh-CN")
+
+	lang, from := d.GetFrom()
+	a.Equal(lang, "zh-CN").Equal(from, "/etc/locale.conf")
+
+	// Codeset 不会跳过 C
+	a.Equal(d.Codeset(), "UTF-8")
+}
+
+func TestDetector_Codeset(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := map[string]string{
+		"LC_CTYPE": "zh_CN.GB18030",
+		"LANG":     "en_US.UTF-8",
+	}
+	d := newDetector(env, nil, SourceEnv)
+	a.Equal(d.Codeset(), "GB18030")
+
+	env["LC_ALL"] = "ja_JP.eucJP@cjk_narrow"
+	a.Equal(d.Codeset(), "eucJP")
+
+	d = newDetector(map[string]string{"LANG": "C"}, nil, SourceEnv)
+	a.Empty(d.Codeset())
+
+	d = newDetector(nil, map[string]string{"etc/locale.conf": "LANG=ko_KR.EUC-KR"}, SourceEnv, SourceFiles)
+	a.Equal(d.Codeset(), "EUC-KR")
+
+	d = newDetector(nil, nil, SourceEnv)
+	a.Empty(d.Codeset())
+}
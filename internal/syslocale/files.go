@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2020-2024 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+)
+
+// 配置文件中的变量
+type varsFile struct {
+	path string // 文件的绝对路径
+	vars map[string]string
+}
+
+// 系统级别的配置文件，按优先级排列。
+var systemFiles = []string{
+	"/etc/locale.conf",    // systemd
+	"/etc/default/locale", // debian、ubuntu
+	"/etc/sysconfig/i18n", // rhel、centos 6 及更早的版本
+}
+
+// 按顺序返回 keys 中第一个不为空的值
+func lookup(vars map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if val := vars[key]; val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// 返回所有需要读取的配置文件，按优先级排列：
+//   - XDG_CONFIG_HOME/locale.conf，未设置 XDG_CONFIG_HOME 时为 HOME/.config/locale.conf；
+//   - HOME/locale.conf、HOME/.i18n 和 HOME/.pam_environment；
+//   - systemFiles 中的系统级别配置文件；
+func (d *Detector) files() []string {
+	home := d.getenv("HOME")
+	if !path.IsAbs(home) {
+		home = ""
+	}
+
+	files := make([]string, 0, 4+len(systemFiles))
+	if dir := d.getenv("XDG_CONFIG_HOME"); path.IsAbs(dir) {
+		files = append(files, path.Join(dir, "locale.conf"))
+	} else if home != "" {
+		files = append(files, path.Join(home, ".config", "locale.conf"))
+	}
+	if home != "" {
+		files = append(files,
+			path.Join(home, "locale.conf"),
+			path.Join(home, ".i18n"), // opensuse
+			path.Join(home, ".pam_environment"),
+		)
+	}
+	return append(files, systemFiles...)
+}
+
+// 按优先级读取所有的配置文件，不存在或是内容为空的文件会被忽略。
+func (d *Detector) readFiles() []*varsFile {
+	fsys := d.fsys()
+	files := d.files()
+	vfs := make([]*varsFile, 0, len(files))
+	for _, p := range files {
+		if vars := readFromFile(fsys, p); len(vars) > 0 {
+			vfs = append(vfs, &varsFile{path: p, vars: vars})
+		}
+	}
+	return vfs
+}
+
+// 读取 fsys 中 p 文件的所有变量
+//
+// p 为以 / 开头的绝对路径。
+func readFromFile(fsys fs.FS, p string) map[string]string {
+	f, err := fsys.Open(strings.TrimPrefix(p, "/"))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Println(err)
+		}
+		return nil
+	}
+	defer f.Close()
+
+	vars, err := parseVars(f)
+	if err != nil {
+		log.Println(err)
+	}
+	return vars
+}
+
+// 解析 shell 风格的变量赋值
+//
+// 支持以下格式：
+//
+//	# 注释
+//	LANG=zh_CN.UTF-8
+//	export LC_ALL="zh_CN.UTF-8"
+//	LC_TIME='en_GB.UTF-8' # 注释
+//	LANGUAGE DEFAULT=zh_CN:en OVERRIDE=${LANGUAGE}
+//
+// 最后一种为 pam_env 的格式，OVERRIDE 优先于 DEFAULT，包含变量引用的值会被忽略。
+func parseVars(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string, 5)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if rest, found := strings.CutPrefix(line, "export"); found && rest != "" && isBlank(rest[0]) {
+			line = strings.TrimLeft(rest, " \t")
+		}
+
+		end := strings.IndexAny(line, " \t=")
+		if end <= 0 {
+			continue
+		}
+		key := line[:end]
+
+		if line[end] == '=' {
+			if val, _ := parseValue(line[end+1:]); val != "" && !strings.Contains(val, "$") {
+				vars[key] = val
+			}
+			continue
+		}
+
+		if val := parsePAMOptions(line[end:]); val != "" {
+			vars[key] = val
+		}
+	}
+
+	return vars, scanner.Err()
+}
+
+// 解析 pam_env 格式中的 DEFAULT 和 OVERRIDE 选项
+func parsePAMOptions(s string) string {
+	var def, override string
+	for {
+		s = strings.TrimLeft(s, " \t")
+
+		var val string
+		switch {
+		case strings.HasPrefix(s, "DEFAULT="):
+			val, s = parseValue(s[len("DEFAULT="):])
+			def = val
+		case strings.HasPrefix(s, "OVERRIDE="):
+			val, s = parseValue(s[len("OVERRIDE="):])
+			override = val
+		default:
+			if override != "" && !strings.Contains(override, "$") {
+				return override
+			}
+			if !strings.Contains(def, "$") {
+				return def
+			}
+			return ""
+		}
+	}
+}
+
+// 解析 shell 中的值
+//
+// 值以未被引号包含的空白字符结束，返回值 rest 为剩余的部分。
+func parseValue(s string) (val, rest string) {
+	b := &strings.Builder{}
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				b.WriteByte(c)
+			}
+		case c == '\\' && i+1 < len(s):
+			i++
+			b.WriteByte(s[i])
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			} else {
+				b.WriteByte(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case isBlank(c):
+			return b.String(), s[i:]
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), ""
+}
+
+func isBlank(c byte) bool { return c == ' ' || c == '\t' }
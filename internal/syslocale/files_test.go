@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/issue9/assert/v4"
+)
+
+func TestParseVars(t *testing.T) {
+	a := assert.New(t, false)
+
+	vars, err := parseVars(strings.NewReader(`
+# comment
+LANG=zh_CN.UTF-8
+  export LC_ALL="en US.UTF-8"
+export	LC_TIME='en_GB.UTF-8' # comment
+LC_NUMERIC=de_DE\ x
+LC_PAPER=${LANG}
+LC_COLLATE=
+=invalid
+exporter=v1
+LANGUAGE DEFAULT=zh_CN:en OVERRIDE=${LANGUAGE}
+LC_MONETARY	DEFAULT=ja_JP OVERRIDE="fr_FR.UTF-8"
+LC_NAME DEFAULT=${HOME}
+`))
+	a.NotError(err).Equal(vars, map[string]string{
+		"LANG":        "zh_CN.UTF-8",
+		"LC_ALL":      "en US.UTF-8",
+		"LC_TIME":     "en_GB.UTF-8",
+		"LC_NUMERIC":  "de_DE x",
+		"exporter":    "v1",
+		"LANGUAGE":    "zh_CN:en",
+		"LC_MONETARY": "fr_FR.UTF-8",
+	})
+}
+
+func TestParseValue(t *testing.T) {
+	a := assert.New(t, false)
+
+	val, rest := parseValue(`zh_CN # comment`)
+	a.Equal(val, "zh_CN").Equal(rest, " # comment")
+
+	val, rest = parseValue(`"a b"'c d'e`)
+	a.Equal(val, "a bc de").Empty(rest)
+
+	val, rest = parseValue(`"a \"b\""`)
+	a.Equal(val, `a "b"`).Empty(rest)
+
+	val, rest = parseValue(`'a\b'`)
+	a.Equal(val, `a\b`).Empty(rest)
+}
+
+func TestDetector_readFiles(t *testing.T) {
+	a := assert.New(t, false)
+
+	fsys := fstest.MapFS{
+		"home/u/.i18n":            &fstest.MapFile{Data: []byte("RC_LANG=x\nLANG=\"zh_TW.UTF-8\"\n")},
+		"home/u/.pam_environment": &fstest.MapFile{Data: []byte("LANG DEFAULT=ja_JP.UTF-8\n")},
+		"home/u/locale.conf":      &fstest.MapFile{Data: []byte("# empty\n")},
+		"etc/default/locale":      &fstest.MapFile{Data: []byte("LANG=en_US.UTF-8\n")},
+		"etc/sysconfig/i18n":      &fstest.MapFile{Data: []byte("LANG=\"fr_FR.UTF-8\"\n")},
+	}
+	d := &Detector{
+		Getenv: func(key string) string {
+			if key == "HOME" {
+				return "/home/u"
+			}
+			return ""
+		},
+		FS: fsys,
+	}
+
+	files := d.readFiles()
+	a.Length(files, 4)
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.path)
+	}
+	a.Equal(paths, []string{"/home/u/.i18n", "/home/u/.pam_environment", "/etc/default/locale", "/etc/sysconfig/i18n"})
+
+	// 相对路径的 HOME 会被忽略
+	d.Getenv = func(string) string { return "home/u" }
+	a.Equal(d.files(), systemFiles)
+}
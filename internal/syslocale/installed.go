@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	localeDir     = "usr/lib/locale"
+	localeArchive = "usr/lib/locale/locale-archive"
+	supportedFile = "usr/share/i18n/SUPPORTED"
+
+	archiveMagic      uint32 = 0xde020109 // glibc 中 locarchive.h 的 AR_MAGIC
+	archiveHeaderSize        = 14 * 4     // struct locarhead
+	nameHashEntSize          = 3 * 4      // struct namehashent
+)
+
+var errInvalidArchive = errors.New("syslocale: invalid locale-archive")
+
+// Installed 返回系统中已生成的本地化信息
+//
+// fsys 为系统的根目录，为空表示 os.DirFS("/")，依次读取以下内容：
+//   - /usr/lib/locale/locale-archive，由 localedef 生成的二进制文件；
+//   - /usr/lib/locale 下包含 LC_IDENTIFICATION 文件的目录；
+//
+// 返回值按名称排序，名称相同(编码名称不区分大小写以及 - 和 _)的只保留一个，
+// C、POSIX 等无法表示具体语言的会被忽略。
+func Installed(fsys fs.FS) ([]*Locale, error) {
+	if fsys == nil {
+		fsys = os.DirFS("/")
+	}
+
+	names, err := readArchive(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := readLocaleDirs(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLocales(append(names, dirs...)), nil
+}
+
+// Supported 返回系统支持的本地化信息
+//
+// 读取 fsys 中的 /usr/share/i18n/SUPPORTED，fsys 为空表示 os.DirFS("/")。
+// 与 [Installed] 不同，该文件列出的是可以通过 localedef 生成的本地化信息，
+// 未生成的本地化信息并不能被系统使用。
+//
+// 返回值的规则与 [Installed] 相同。
+func Supported(fsys fs.FS) ([]*Locale, error) {
+	if fsys == nil {
+		fsys = os.DirFS("/")
+	}
+
+	names, err := readSupported(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return toLocales(names), nil
+}
+
+// 将 names 转换为排序且去重之后的 [Locale]
+func toLocales(names []string) []*Locale {
+	locales := make(map[string]*Locale, len(names))
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		l, err := Parse(name)
+		if err != nil || l.Tag == language.Und {
+			continue
+		}
+
+		key := localeKey(l)
+		if _, found := locales[key]; !found {
+			locales[key] = l
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+	ls := make([]*Locale, 0, len(keys))
+	for _, key := range keys {
+		ls = append(ls, locales[key])
+	}
+	return ls
+}
+
+// 用于判断两个 [Locale] 是否相同的键名
+func localeKey(l *Locale) string {
+	key := l.Tag.String()
+	if l.Codeset != "" {
+		key += "." + NormalizeCodeset(l.Codeset)
+	}
+	if l.Modifier != "" {
+		key += "@" + l.Modifier
+	}
+	return key
+}
+
+// NormalizeCodeset 规范化编码名称
+//
+// 与 glibc 的规则相同，仅保留字母和数字，且字母转换为小写，比如 UTF-8 转换为 utf8。
+func NormalizeCodeset(cs string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return -1
+		}
+	}, cs)
+}
+
+// 读取 locale-archive 中的本地化名称
+//
+// 文件格式可参考 glibc 中的 locarchive.h，头部由 14 个 uint32 组成，
+// 其中第 3 和第 5 个分别为名称哈希表的偏移量和大小，
+// 哈希表的每一项由 hashval、name_offset 和 locrec_offset 组成，locrec_offset 为 0 表示该项未使用。
+// 所有的整数均采用生成该文件的系统的字节序。
+func readArchive(fsys fs.FS) ([]string, error) {
+	data, err := fs.ReadFile(fsys, localeArchive)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(data) < archiveHeaderSize {
+		return nil, errInvalidArchive
+	}
+
+	var order binary.ByteOrder
+	switch archiveMagic {
+	case binary.LittleEndian.Uint32(data):
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(data):
+		order = binary.BigEndian
+	default:
+		return nil, errInvalidArchive
+	}
+
+	offset := uint64(order.Uint32(data[8:]))
+	size := uint64(order.Uint32(data[16:]))
+	if offset > uint64(len(data)) || size > (uint64(len(data))-offset)/nameHashEntSize {
+		return nil, errInvalidArchive
+	}
+
+	names := make([]string, 0, 10)
+	for i := uint64(0); i < size; i++ {
+		ent := data[offset+i*nameHashEntSize:]
+		nameOffset := uint64(order.Uint32(ent[4:]))
+		if nameOffset == 0 || order.Uint32(ent[8:]) == 0 {
+			continue
+		}
+
+		if nameOffset >= uint64(len(data)) {
+			return nil, errInvalidArchive
+		}
+		name := data[nameOffset:]
+		end := bytes.IndexByte(name, 0)
+		if end < 0 {
+			return nil, errInvalidArchive
+		}
+		names = append(names, string(name[:end]))
+	}
+
+	return names, nil
+}
+
+// 读取 /usr/lib/locale 下包含 LC_IDENTIFICATION 的目录名
+func readLocaleDirs(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, localeDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		if _, err := fs.Stat(fsys, path.Join(localeDir, e.Name(), "LC_IDENTIFICATION")); err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// 读取 /usr/share/i18n/SUPPORTED 中的本地化名称
+//
+// 每一行的格式为 name codeset，比如：
+//
+//	en_US.UTF-8 UTF-8
+//	zh_CN GB2312
+//
+// 如果名称中不包含编码，则将第二列的编码添加到名称中。
+func readSupported(fsys fs.FS) ([]string, error) {
+	f, err := fsys.Open(supportedFile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	names := make([]string, 0, 100)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		name := fields[0]
+		if len(fields) > 1 && !strings.Contains(name, ".") {
+			lang, modifier, found := strings.Cut(name, "@")
+			name = lang + "." + fields[1]
+			if found {
+				name += "@" + modifier
+			}
+		}
+		names = append(names, name)
+	}
+	return names, scanner.Err()
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"encoding/binary"
+	"testing"
+	"testing/fstest"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+// 生成只包含名称哈希表的 locale-archive 文件
+func buildArchive(order binary.ByteOrder, names ...string) []byte {
+	size := len(names) + 1 // 多一个未使用的项
+	hashOffset := archiveHeaderSize
+	strOffset := hashOffset + size*nameHashEntSize
+
+	data := make([]byte, strOffset)
+	order.PutUint32(data, archiveMagic)
+	order.PutUint32(data[8:], uint32(hashOffset))
+	order.PutUint32(data[12:], uint32(len(names)))
+	order.PutUint32(data[16:], uint32(size))
+
+	for i, name := range names {
+		ent := data[hashOffset+(i+1)*nameHashEntSize:]
+		order.PutUint32(ent, uint32(i+1)) // hashval
+		order.PutUint32(ent[4:], uint32(len(data)))
+		order.PutUint32(ent[8:], 1) // locrec_offset
+		data = append(data, name...)
+		data = append(data, 0)
+	}
+
+	return data
+}
+
+func TestReadArchive(t *testing.T) {
+	a := assert.New(t, false)
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		fsys := fstest.MapFS{localeArchive: &fstest.MapFile{Data: buildArchive(order, "en_US.utf8", "zh_CN.gb18030")}}
+		names, err := readArchive(fsys)
+		a.NotError(err).Equal(names, []string{"en_US.utf8", "zh_CN.gb18030"})
+	}
+
+	// 不存在
+	names, err := readArchive(fstest.MapFS{})
+	a.NotError(err).Empty(names)
+
+	// magic 错误
+	data := buildArchive(binary.LittleEndian, "en_US.utf8")
+	data[0] = 0
+	_, err = readArchive(fstest.MapFS{localeArchive: &fstest.MapFile{Data: data}})
+	a.Equal(err, errInvalidArchive)
+
+	// 哈希表大小超出文件范围
+	data = buildArchive(binary.LittleEndian, "en_US.utf8")
+	binary.LittleEndian.PutUint32(data[16:], 1000)
+	_, err = readArchive(fstest.MapFS{localeArchive: &fstest.MapFile{Data: data}})
+	a.Equal(err, errInvalidArchive)
+
+	// 名称没有结束符
+	data = buildArchive(binary.LittleEndian, "en_US.utf8")
+	_, err = readArchive(fstest.MapFS{localeArchive: &fstest.MapFile{Data: data[:len(data)-1]}})
+	a.Equal(err, errInvalidArchive)
+
+	_, err = readArchive(fstest.MapFS{localeArchive: &fstest.MapFile{Data: []byte{1, 2}}})
+	a.Equal(err, errInvalidArchive)
+}
+
+func TestInstalled(t *testing.T) {
+	a := assert.New(t, false)
+
+	fsys := fstest.MapFS{
+		localeArchive: &fstest.MapFile{Data: buildArchive(binary.LittleEndian, "en_US.utf8", "zh_CN.gb18030", "C.utf8")},
+		"usr/lib/locale/ja_JP.eucjp/LC_IDENTIFICATION": &fstest.MapFile{},
+		"usr/lib/locale/de_DE.utf8/LC_CTYPE":           &fstest.MapFile{}, // 不包含 LC_IDENTIFICATION
+		"usr/lib/locale/C.utf8/LC_IDENTIFICATION":      &fstest.MapFile{},
+		"usr/lib/locale/en_US.UTF-8/LC_IDENTIFICATION": &fstest.MapFile{}, // 与 locale-archive 中的相同
+		supportedFile: &fstest.MapFile{Data: []byte("fr_FR.UTF-8 UTF-8\n")}, // 不会被读取
+	}
+
+	ls, err := Installed(fsys)
+	a.NotError(err).Length(ls, 3)
+
+	tags := make([]string, 0, len(ls))
+	codesets := make([]string, 0, len(ls))
+	for _, l := range ls {
+		tags = append(tags, l.Tag.String())
+		codesets = append(codesets, l.Codeset)
+	}
+	a.Equal(tags, []string{"en-US", "ja-JP", "zh-CN"}).
+		Equal(codesets, []string{"utf8", "eucjp", "gb18030"})
+
+	ls, err = Installed(fstest.MapFS{})
+	a.NotError(err).Empty(ls)
+}
+
+func TestSupported(t *testing.T) {
+	a := assert.New(t, false)
+
+	fsys := fstest.MapFS{
+		"usr/lib/locale/ja_JP.eucjp/LC_IDENTIFICATION": &fstest.MapFile{}, // 不会被读取
+		supportedFile: &fstest.MapFile{Data: []byte(`# comment
+en_US.UTF-8 UTF-8
+zh_CN GB2312
+zh_CN.GB2312 GB2312
+sr_RS@latin UTF-8
+`)},
+	}
+
+	ls, err := Supported(fsys)
+	a.NotError(err).Length(ls, 3)
+
+	tags := make([]string, 0, len(ls))
+	codesets := make([]string, 0, len(ls))
+	for _, l := range ls {
+		tags = append(tags, l.Tag.String())
+		codesets = append(codesets, l.Codeset)
+	}
+	a.Equal(tags, []string{"en-US", "sr-Latn-RS", "zh-CN"}).
+		Equal(codesets, []string{"UTF-8", "UTF-8", "GB2312"})
+	a.Equal(ls[1].Modifier, "latin").Equal(ls[1].Tag, language.MustParse("sr-Latn-RS"))
+
+	ls, err = Supported(fstest.MapFS{})
+	a.NotError(err).Empty(ls)
+}
+
+func TestNormalizeCodeset(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(NormalizeCodeset("UTF-8"), "utf8").
+		Equal(NormalizeCodeset("ISO-8859-1"), "iso88591").
+		Equal(NormalizeCodeset("eucJP"), "eucjp").
+		Equal(NormalizeCodeset(""), "")
+}
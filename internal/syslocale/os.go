@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+// 平台相关接口返回的本地化信息
+type osLocales struct {
+	// 区域格式
+	//
+	// 比如 windows 下的 GetUserDefaultLocaleName 和 macOS 下的 AppleLocale。
+	locale     string
+	localeFrom string
+
+	// 按优先级排列的界面语言
+	//
+	// 比如 windows 下的 GetUserPreferredUILanguages 和 macOS 下的 AppleLanguages。
+	languages     []string
+	languagesFrom string
+}
+
+// 返回 [SourceOS] 中的值
+//
+// 区域格式作为第一个值，与之前的版本保持一致，[Detector.Get] 返回的依然是区域格式；
+// 界面语言的列表则排在其后，由 [Detector.GetAll] 等返回所有值的方法返回。
+func (l *osLocales) candidates() []*candidate {
+	if l == nil {
+		return nil
+	}
+
+	cs := make([]*candidate, 0, len(l.languages)+1)
+	if l.locale != "" {
+		cs = append(cs, &candidate{from: l.localeFrom, raw: l.locale})
+	}
+	for _, lang := range l.languages {
+		cs = append(cs, &candidate{from: l.languagesFrom, raw: lang})
+	}
+	return cs
+}
+
+func getOSLocaleNames() []*candidate { return getOSLocales().candidates() }
+
+// 返回分类 c 对应的值
+//
+// [Messages] 对应界面语言，其它分类则对应区域格式，比如界面语言为英文，
+// 而日期和数值采用德国的格式时，[Time] 应该返回的是区域格式 de-DE。
+// 如果对应的值不存在，则采用另一个值。
+func (l *osLocales) category(c Category) string {
+	if l == nil {
+		return ""
+	}
+
+	var lang string
+	if len(l.languages) > 0 {
+		lang = l.languages[0]
+	}
+
+	if c == Messages {
+		if lang != "" {
+			return lang
+		}
+		return l.locale
+	}
+
+	if l.locale != "" {
+		return l.locale
+	}
+	return lang
+}
+
+func getOSCategory(c Category) string { return getOSLocales().category(c) }
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func newOSLocales() *osLocales {
+	return &osLocales{
+		locale:        "de-DE",
+		localeFrom:    "GetUserDefaultLocaleName",
+		languages:     []string{"en-US", "zh-Hans-CN"},
+		languagesFrom: "GetUserPreferredUILanguages",
+	}
+}
+
+func TestOSLocales_candidates(t *testing.T) {
+	a := assert.New(t, false)
+
+	var l *osLocales
+	a.Empty(l.candidates())
+
+	// 区域格式在前
+	a.Equal(newOSLocales().candidates(), []*candidate{
+		{from: "GetUserDefaultLocaleName", raw: "de-DE"},
+		{from: "GetUserPreferredUILanguages", raw: "en-US"},
+		{from: "GetUserPreferredUILanguages", raw: "zh-Hans-CN"},
+	})
+
+	l = &osLocales{languages: []string{"en-US"}, languagesFrom: "AppleLanguages"}
+	a.Equal(l.candidates(), []*candidate{{from: "AppleLanguages", raw: "en-US"}})
+}
+
+func TestOSLocales_category(t *testing.T) {
+	a := assert.New(t, false)
+
+	var l *osLocales
+	a.Empty(l.category(Messages))
+
+	// 界面语言为 en-US，区域格式为 de-DE
+	l = newOSLocales()
+	a.Equal(l.category(Messages), "en-US")
+	for _, c := range []Category{Time, Numeric, Monetary, Collate, CType} {
+		a.Equal(l.category(c), "de-DE", c)
+	}
+
+	// 仅有区域格式
+	l = &osLocales{locale: "de-DE"}
+	a.Equal(l.category(Messages), "de-DE").Equal(l.category(Time), "de-DE")
+
+	// 仅有界面语言
+	l = &osLocales{languages: []string{"en-US"}}
+	a.Equal(l.category(Messages), "en-US").Equal(l.category(Time), "en-US")
+}
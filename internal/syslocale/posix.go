@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Locale POSIX 格式的本地化名称
+//
+// 格式为 language[_territory][.codeset][@modifier]，比如 zh_CN.UTF-8、sr_RS@latin 等。
+type Locale struct {
+	Language  string // 语言，比如 zh_CN.UTF-8 中的 zh
+	Territory string // 地区，比如 zh_CN.UTF-8 中的 CN
+	Codeset   string // 编码，比如 zh_CN.UTF-8 中的 UTF-8
+	Modifier  string // 修饰符，比如 sr_RS@latin 中的 latin
+
+	// 转换后的 [language.Tag] 对象
+	//
+	// 修饰符 latin、cyrillic 等会被转换为对应的 script，valencia 会被转换为 variant。
+	// C 和 POSIX 为 [language.Und]。
+	Tag language.Tag
+}
+
+// 修饰符与 script 的对应关系
+var modifierScripts = map[string]string{
+	"latin":      "Latn",
+	"cyrillic":   "Cyrl",
+	"devanagari": "Deva",
+	"arabic":     "Arab",
+}
+
+// 修饰符与 variant 的对应关系
+var modifierVariants = map[string]string{
+	"valencia": "valencia",
+}
+
+var errEmptyLocale = errors.New("syslocale: empty locale")
+
+// Parse 解析 POSIX 格式的本地化名称
+//
+// 同时也支持 BCP 47 格式的名称，比如 zh-Hans-CN。
+// C、POSIX 以及 C.UTF-8 等均被解析为 [language.Und]。
+func Parse(s string) (*Locale, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errEmptyLocale
+	}
+
+	l := &Locale{}
+	s, l.Modifier, _ = strings.Cut(s, "@")
+	s, l.Codeset, _ = strings.Cut(s, ".")
+
+	if s == "C" || s == "POSIX" {
+		l.Language = s
+		l.Tag = language.Und
+		return l, nil
+	}
+
+	tag, err := language.Parse(strings.ReplaceAll(s, "_", "-"))
+	if err != nil {
+		return nil, err
+	}
+
+	if lang, territory, found := strings.Cut(s, "_"); found {
+		l.Language = lang
+		l.Territory = territory
+	} else {
+		base, _ := tag.Base()
+		l.Language = base.String()
+		if region, conf := tag.Region(); conf == language.Exact {
+			l.Territory = region.String()
+		}
+	}
+
+	if script, found := modifierScripts[strings.ToLower(l.Modifier)]; found {
+		if tag, err = language.Compose(tag, language.MustParseScript(script)); err != nil {
+			return nil, err
+		}
+	} else if variant, found := modifierVariants[strings.ToLower(l.Modifier)]; found {
+		v, err := language.ParseVariant(variant)
+		if err != nil {
+			return nil, err
+		}
+		if tag, err = language.Compose(tag, v); err != nil {
+			return nil, err
+		}
+	}
+	l.Tag = tag
+
+	return l, nil
+}
+
+// 将 POSIX 格式的本地化名称转换为 BCP 47 格式
+//
+// 包含 ICU 关键字的值，比如 en_US@rg=gbzzzz，由 [ParseApple] 进行转换。
+// C、POSIX 等不表示具体语言的值返回空字符串，以便继续读取优先级较低的来源；
+// 其它无法解析的值原样返回。
+func trimLocale(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return ""
+	}
+
+	if _, keywords, found := strings.Cut(v, "@"); found && strings.Contains(keywords, "=") {
+		if tag, err := ParseApple(v); err == nil {
+			return tag.String()
+		}
+		return v
+	}
+
+	l, err := Parse(v)
+	if err != nil {
+		return v
+	}
+	if l.Tag == language.Und {
+		return ""
+	}
+	return l.Tag.String()
+}
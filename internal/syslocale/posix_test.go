@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func TestParse(t *testing.T) {
+	a := assert.New(t, false)
+
+	data := []struct {
+		input string
+		l     *Locale
+	}{
+		{
+			input: "zh_CN.UTF-8",
+			l:     &Locale{Language: "zh", Territory: "CN", Codeset: "UTF-8", Tag: language.MustParse("zh-CN")},
+		},
+		{
+			input: "zh_CN",
+			l:     &Locale{Language: "zh", Territory: "CN", Tag: language.MustParse("zh-CN")},
+		},
+		{
+			input: " en ",
+			l:     &Locale{Language: "en", Tag: language.English},
+		},
+		{
+			input: "sr_RS@latin",
+			l:     &Locale{Language: "sr", Territory: "RS", Modifier: "latin", Tag: language.MustParse("sr-Latn-RS")},
+		},
+		{
+			input: "sr_RS.UTF-8@cyrillic",
+			l:     &Locale{Language: "sr", Territory: "RS", Codeset: "UTF-8", Modifier: "cyrillic", Tag: language.MustParse("sr-Cyrl-RS")},
+		},
+		{
+			input: "de_DE.UTF-8@euro",
+			l:     &Locale{Language: "de", Territory: "DE", Codeset: "UTF-8", Modifier: "euro", Tag: language.MustParse("de-DE")},
+		},
+		{
+			input: "ca_ES@valencia",
+			l:     &Locale{Language: "ca", Territory: "ES", Modifier: "valencia", Tag: language.MustParse("ca-ES-valencia")},
+		},
+		{
+			input: "ja_JP.eucJP",
+			l:     &Locale{Language: "ja", Territory: "JP", Codeset: "eucJP", Tag: language.MustParse("ja-JP")},
+		},
+		{
+			input: "zh-Hans-CN",
+			l:     &Locale{Language: "zh", Territory: "CN", Tag: language.MustParse("zh-Hans-CN")},
+		},
+		{
+			input: "zh-Hans",
+			l:     &Locale{Language: "zh", Tag: language.MustParse("zh-Hans")},
+		},
+		{
+			input: "C",
+			l:     &Locale{Language: "C", Tag: language.Und},
+		},
+		{
+			input: "C.UTF-8",
+			l:     &Locale{Language: "C", Codeset: "UTF-8", Tag: language.Und},
+		},
+		{
+			input: "POSIX",
+			l:     &Locale{Language: "POSIX", Tag: language.Und},
+		},
+	}
+
+	for _, item := range data {
+		l, err := Parse(item.input)
+		a.NotError(err, item.input).Equal(l, item.l, item.input)
+	}
+
+	l, err := Parse("")
+	a.Equal(err, errEmptyLocale).Nil(l)
+
+	l, err = Parse("zh_CN_中文")
+	a.Error(err).Nil(l)
+}
+
+func TestTrimLocale(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(trimLocale(""), "").
+		Equal(trimLocale("zh_CN.UTF-8"), "zh-CN").
+		Equal(trimLocale("sr_RS@latin"), "sr-Latn-RS").
+		Equal(trimLocale("C"), "").
+		Equal(trimLocale("C.UTF-8"), "").
+		Equal(trimLocale("POSIX"), "").
+		Equal(trimLocale("xxx_yyy_zzz"), "xxx_yyy_zzz")
+}
@@ -5,36 +5,20 @@
 // Package syslocale 获取所在系统的本地化语言信息
 package syslocale
 
-import (
-	"os"
-	"strings"
-)
+var defaultDetector = &Detector{}
 
 // Get 返回当前系统的本地化信息
-func Get() string {
-	if lang := getEnv("LANGUAGE"); lang != "" {
-		return lang
-	}
-
-	if lang := getOSLocaleName(); lang != "" {
-		return trimLocale(lang)
-	}
-
-	for _, env := range [...]string{"LC_ALL", "LC_MESSAGES", "LANG"} {
-		if lang := getEnv(env); lang != "" {
-			return lang
-		}
-	}
-
-	return ""
-}
+//
+// 返回值为 BCP 47 格式，比如 zh_CN.UTF-8 会被转换为 zh-CN。
+// 相当于 [Detector.Get] 的零值调用。
+func Get() string { return defaultDetector.Get() }
 
-func getEnv(env string) string { return trimLocale(os.Getenv(env)) }
+// GetAll 按优先级返回用户设置的所有本地化信息
+//
+// 相当于 [Detector.GetAll] 的零值调用。
+func GetAll() []string { return defaultDetector.GetAll() }
 
-// zh_CN.UTF-8 过滤掉最后的编码方式
-func trimLocale(v string) string {
-	if index := strings.LastIndexByte(v, '.'); index > 0 {
-		v = v[:index]
-	}
-	return strings.TrimSpace(v)
-}
+// GetCategory 返回分类 c 的本地化信息
+//
+// 相当于 [Detector.GetCategory] 的零值调用。
+func GetCategory(c Category) string { return defaultDetector.GetCategory(c) }
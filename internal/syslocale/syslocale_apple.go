@@ -8,11 +8,19 @@ package syslocale
 
 import "github.com/issue9/localeutil/internal/defaults"
 
-func getOSLocaleName() string {
-	return defaults.ReadDomains(
-		"AppleLocale",
-		"~/Library/Preferences/.GlobalPreferences",
-		"/Library/Preferences/.GlobalPreferences",
-		"-g",
-	)
+var domains = []string{
+	"~/Library/Preferences/.GlobalPreferences",
+	"/Library/Preferences/.GlobalPreferences",
+	"-g",
+}
+
+var defaultSources = []Source{SourceLanguage, SourceOS, SourceEnv}
+
+func getOSLocales() *osLocales {
+	return &osLocales{
+		locale:        defaults.ReadDomains("AppleLocale", domains...),
+		localeFrom:    "AppleLocale",
+		languages:     defaults.ReadDomainsArray("AppleLanguages", domains...),
+		languagesFrom: "AppleLanguages",
+	}
 }
@@ -8,16 +8,23 @@ import "syscall/js"
 
 func getNavigator() js.Value { return js.Global().Get("navigator") }
 
-func getOSLocaleName() string {
+var defaultSources = []Source{SourceLanguage, SourceOS, SourceEnv}
+
+func getOSLocales() *osLocales {
 	nav := getNavigator()
 	if nav.IsUndefined() {
-		return ""
+		return nil
 	}
 
-	lang := nav.Get("language")
-	if lang.IsUndefined() {
-		return ""
+	l := &osLocales{localeFrom: "navigator.language", languagesFrom: "navigator.languages"}
+	if lang := nav.Get("language"); !lang.IsUndefined() {
+		l.locale = lang.String()
 	}
-
-	return lang.String()
+	if list := nav.Get("languages"); !list.IsUndefined() && !list.IsNull() {
+		l.languages = make([]string, 0, list.Length())
+		for i := 0; i < list.Length(); i++ {
+			l.languages = append(l.languages, list.Index(i).String())
+		}
+	}
+	return l
 }
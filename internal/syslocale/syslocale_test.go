@@ -13,6 +13,26 @@ import (
 func TestGet(t *testing.T) {
 	a := assert.New(t, false)
 
-	name := Get()
-	a.True(len(name) > 0)
+	t.Setenv("LANGUAGE", "zh_CN.UTF-8:en")
+	a.Equal(Get(), "zh-CN")
+}
+
+func TestGetAll(t *testing.T) {
+	a := assert.New(t, false)
+
+	t.Setenv("LANGUAGE", "zh_CN.UTF-8:zh_TW::en")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "fr_FR.UTF-8")
+	t.Setenv("LANG", "ja_JP.UTF-8")
+
+	langs := GetAll()
+	a.True(len(langs) >= 5).
+		Equal(langs[:3], []string{"zh-CN", "zh-TW", "en"}).
+		Equal(langs[len(langs)-2:], []string{"fr-FR", "ja-JP"})
+
+	a.Equal(Get(), "zh-CN")
+
+	t.Setenv("LANGUAGE", "")
+	langs = GetAll()
+	a.Equal(langs[len(langs)-2:], []string{"fr-FR", "ja-JP"})
 }
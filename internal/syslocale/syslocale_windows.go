@@ -12,7 +12,20 @@ import (
 	"github.com/issue9/localeutil/internal/dll"
 )
 
-func getOSLocaleName() string {
+const muiLanguageName = 0x8 // GetUserPreferredUILanguages 的 MUI_LANGUAGE_NAME
+
+var defaultSources = []Source{SourceLanguage, SourceOS, SourceEnv}
+
+func getOSLocales() *osLocales {
+	return &osLocales{
+		locale:        getUserDefaultLocaleName(),
+		localeFrom:    "GetUserDefaultLocaleName",
+		languages:     getUserPreferredUILanguages(),
+		languagesFrom: "GetUserPreferredUILanguages",
+	}
+}
+
+func getUserDefaultLocaleName() string {
 	f := dll.Kernel32().NewProc("GetUserDefaultLocaleName")
 
 	const maxLen = 85 // GetUserDefaultLocaleName 第二个参数
@@ -24,3 +37,35 @@ func getOSLocaleName() string {
 	}
 	return syscall.UTF16ToString(buf)
 }
+
+func getUserPreferredUILanguages() []string {
+	f := dll.Kernel32().NewProc("GetUserPreferredUILanguages")
+
+	var num, size uint32
+	r1, _, err := f.Call(muiLanguageName, uintptr(unsafe.Pointer(&num)), 0, uintptr(unsafe.Pointer(&size)))
+	if uint32(r1) == 0 || size == 0 {
+		log.Println(err)
+		return nil
+	}
+
+	buf := make([]uint16, size)
+	r1, _, err = f.Call(muiLanguageName, uintptr(unsafe.Pointer(&num)), uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if uint32(r1) == 0 {
+		log.Println(err)
+		return nil
+	}
+
+	// 以 \0 分隔，并以两个 \0 结尾的列表
+	langs := make([]string, 0, num)
+	for start, i := 0, 0; i < len(buf); i++ {
+		if buf[i] != 0 {
+			continue
+		}
+		if i == start {
+			break
+		}
+		langs = append(langs, syscall.UTF16ToString(buf[start:i]))
+		start = i + 1
+	}
+	return langs
+}
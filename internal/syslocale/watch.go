@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultWatchInterval [Detector.Watch] 默认的检测间隔
+const DefaultWatchInterval = 5 * time.Second
+
+// Change 本地化信息的变化
+type Change struct {
+	Old language.Tag
+	New language.Tag
+}
+
+// Watch 监视本地化信息的变化
+//
+// 每隔 interval 检测一次 [Detector.Tag] 的值，如果与上一次的值不同，则调用 f。
+// interval 小于等于 0 时采用 [DefaultWatchInterval]。
+// 调用时的值作为初始值，不会触发 f。无法解析的值被当作 [language.Und] 处理。
+//
+// f 在调用 Watch 的 goroutine 中执行，如果 Watch 是在单独的 goroutine 中运行的，
+// f 中访问的共享数据需要使用 sync.Mutex 或是 atomic.Pointer 等进行同步。
+//
+// 该方法会一直阻塞，直到 ctx 被取消，返回值为 ctx.Err()。
+func (d *Detector) Watch(ctx context.Context, interval time.Duration, f func(*Change)) error {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	old := d.watchTag()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if tag := d.watchTag(); tag != old {
+				f(&Change{Old: old, New: tag})
+				old = tag
+			}
+		}
+	}
+}
+
+// Changes 以通道的形式返回本地化信息的变化
+//
+// 参数及行为与 [Detector.Watch] 相同，但是不会阻塞，ctx 被取消之后会关闭返回的通道。
+func (d *Detector) Changes(ctx context.Context, interval time.Duration) <-chan *Change {
+	ch := make(chan *Change, 1)
+	go func() {
+		defer close(ch)
+
+		d.Watch(ctx, interval, func(c *Change) {
+			select {
+			case ch <- c:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
+
+func (d *Detector) watchTag() language.Tag {
+	tag, err := d.Tag()
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
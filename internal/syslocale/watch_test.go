@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package syslocale
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+type watchEnv struct {
+	mux sync.Mutex
+	env map[string]string
+
+	// 每次检测结束时发送信号
+	//
+	// LANG 是 SourceEnv 中最后读取的环境变量，读取 LANG 即表示一次检测已经完成。
+	reads chan struct{}
+}
+
+func newWatchEnv(env map[string]string) *watchEnv {
+	return &watchEnv{env: env, reads: make(chan struct{}, 1)}
+}
+
+func (e *watchEnv) get(key string) string {
+	e.mux.Lock()
+	v := e.env[key]
+	e.mux.Unlock()
+
+	if key == "LANG" {
+		select {
+		case e.reads <- struct{}{}:
+		default:
+		}
+	}
+	return v
+}
+
+func (e *watchEnv) set(key, val string) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.env[key] = val
+}
+
+func TestDetector_Watch(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := newWatchEnv(map[string]string{"LANG": "zh_CN.UTF-8"})
+	d := &Detector{Getenv: env.get, Sources: []Source{SourceEnv}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan *Change, 10)
+	exit := make(chan error, 1)
+	go func() {
+		exit <- d.Watch(ctx, time.Millisecond, func(c *Change) { changes <- c })
+	}()
+
+	// 初始值以及之后的两次检测，f 与检测在同一个 goroutine 中，
+	// 第二次检测完成时，第一次检测的 f 必然已经执行。
+	<-env.reads
+	<-env.reads
+	<-env.reads
+	a.Length(changes, 0) // 初始值不会触发
+
+	env.set("LANG", "en_US.UTF-8")
+	c := <-changes
+	a.Equal(c.Old, language.MustParse("zh-CN")).
+		Equal(c.New, language.AmericanEnglish)
+
+	env.set("LANG", "")
+	c = <-changes
+	a.Equal(c.Old, language.AmericanEnglish).Equal(c.New, language.Und)
+
+	cancel()
+	a.ErrorIs(<-exit, context.Canceled)
+}
+
+func TestDetector_Changes(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := newWatchEnv(map[string]string{"LANG": "zh_CN.UTF-8"})
+	d := &Detector{Getenv: env.get, Sources: []Source{SourceEnv}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := d.Changes(ctx, time.Millisecond)
+
+	<-env.reads // 初始值已经读取
+	env.set("LANG", "ja_JP.UTF-8")
+	c := <-ch
+	a.Equal(c.Old, language.MustParse("zh-CN")).
+		Equal(c.New, language.MustParse("ja-JP"))
+
+	cancel()
+	for range ch { // 等待通道关闭
+	}
+}
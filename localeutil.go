@@ -6,24 +6,177 @@
 package localeutil
 
 import (
+	"context"
+	"io/fs"
+	"time"
+
 	"golang.org/x/text/language"
 
 	"github.com/issue9/localeutil/internal/syslocale"
 )
 
+// Detector 本地化信息的检测
+//
+// 零值表示检测当前系统的本地化信息，[DetectUserLanguage] 等函数即是对零值的封装。
+// 也可以指定读取环境变量的方法、配置文件所在的文件系统以及来源的优先级，
+// 用于测试或是检测远程会话的本地化信息：
+//
+//	d := &localeutil.Detector{
+//	    Getenv:  func(key string) string { return remoteEnv[key] },
+//	    FS:      remoteFS,
+//	    Sources: []localeutil.LocaleSource{localeutil.SourceLanguage, localeutil.SourceFiles, localeutil.SourceEnv},
+//	}
+//	lang := d.Get()
+//
+// [SourceOS] 始终读取当前系统的设置，不受 Getenv 和 FS 的影响，
+// 所以检测远程会话时需要像上面的示例一样从 Sources 中去掉 [SourceOS]。
+type Detector = syslocale.Detector
+
+// LocaleSource 本地化信息的来源
+type LocaleSource = syslocale.Source
+
+const (
+	SourceLanguage = syslocale.SourceLanguage // 环境变量 LANGUAGE
+	SourceOS       = syslocale.SourceOS       // 平台相关的接口，比如 windows 下的 GetUserDefaultLocaleName，始终读取当前系统的设置
+	SourceFiles    = syslocale.SourceFiles    // 配置文件，比如 linux 下的 locale.conf、/etc/default/locale 等
+	SourceEnv      = syslocale.SourceEnv      // 环境变量 LC_ALL、LC_MESSAGES 和 LANG
+)
+
+var defaultDetector = &Detector{}
+
+// DefaultLocaleSources 当前平台默认的本地化信息来源及其优先级
+func DefaultLocaleSources() []LocaleSource { return syslocale.DefaultSources() }
+
 // DetectUserLanguage 检测当前用户的本地化信息
 //
 // 按以下顺序读取本地化信息：
 //   - 环境变量 LANGUAGE；
-//   - 平台相关，比如 windows 下调用 GetUserDefaultLocaleName 等；
+//   - 平台相关，比如 windows 下调用 GetUserDefaultLocaleName 等，
+//     linux 下则依次读取 ~/.config/locale.conf、~/locale.conf、~/.i18n、~/.pam_environment、
+//     /etc/locale.conf、/etc/default/locale 和 /etc/sysconfig/i18n 等配置文件；
 //   - 按顺序读取 LC_ALL、LC_MESSAGES 和 LANG 环境变量；
 //
-// 所有的环境变量遵守 tag.encoding 的格式，比如 zh_CN.UTF-8，其中的 encoding 可以省略。
-func DetectUserLanguage() string { return syslocale.Get() }
+// 如果需要知道值的出处，可以使用 [DetectUserLanguageResult]。
+//
+// 所有的环境变量遵守 POSIX 的格式，比如 zh_CN.UTF-8，具体可参考 [ParsePOSIXLocale]，
+// 返回值会被转换为 BCP 47 格式，比如 zh-CN；C、POSIX 等不表示具体语言的值会被跳过，
+// 继续读取优先级较低的来源。
+//
+// NOTE: 之前的版本仅去掉了编码部分，返回的是 zh_CN 格式的值，
+// 且 LANG=C 时返回 C，依赖此格式的代码需要作相应的调整。
+func DetectUserLanguage() string { return defaultDetector.Get() }
+
+// LanguageDetection 本地化信息的检测结果及其出处
+type LanguageDetection = syslocale.Result
+
+// DetectUserLanguageResult 检测当前用户的本地化信息及其出处
+//
+// 读取顺序与 [DetectUserLanguage] 相同，返回值包含了原始值、转换后的 [language.Tag]、
+// 值的出处以及所有优先级较低而被忽略的值，可用于调试本地化信息的来源：
+//
+//	if r := localeutil.DetectUserLanguageResult(); r != nil {
+//	    fmt.Print(r)
+//	}
+//
+// 如果没有任何值，返回 nil。
+func DetectUserLanguageResult() *LanguageDetection { return defaultDetector.Detect() }
+
+// LanguageChange 本地化信息的变化
+type LanguageChange = syslocale.Change
+
+// WatchUserLanguage 监视当前用户本地化信息的变化
+//
+// 每隔 interval 调用一次 [DetectUserLanguageTag]，值发生变化时调用 f，
+// interval 小于等于 0 时采用默认值 5 秒。可用于常驻的程序在用户修改语言之后切换界面语言：
+//
+//	var p atomic.Pointer[localeutil.Printer]
+//	p.Store(localeutil.NewPrinter(cat, tag))
+//	go localeutil.WatchUserLanguage(ctx, 0, func(c *localeutil.LanguageChange) {
+//	    p.Store(localeutil.NewPrinter(cat, c.New))
+//	})
+//
+//	p.Load().Printf("hello")
+//
+// f 是在另一个 goroutine 中调用的，与其它 goroutine 共享的数据需要像上面一样作同步处理。
+// 该函数会一直阻塞，直到 ctx 被取消。
+// 如果需要检测其它来源或是以通道的形式接收变化，可以使用 [Detector.Watch] 和 [Detector.Changes]。
+func WatchUserLanguage(ctx context.Context, interval time.Duration, f func(*LanguageChange)) error {
+	return defaultDetector.Watch(ctx, interval, f)
+}
+
+// LocaleCategory 本地化的分类
+type LocaleCategory = syslocale.Category
+
+const (
+	CategoryMessages = syslocale.Messages // LC_MESSAGES
+	CategoryTime     = syslocale.Time     // LC_TIME
+	CategoryNumeric  = syslocale.Numeric  // LC_NUMERIC
+	CategoryMonetary = syslocale.Monetary // LC_MONETARY
+	CategoryCollate  = syslocale.Collate  // LC_COLLATE
+	CategoryCType    = syslocale.CType    // LC_CTYPE
+)
+
+// DetectCategoryLanguage 检测分类 c 的本地化信息
+//
+// 按 POSIX 的规则依次读取以下内容：
+//   - 环境变量 LC_ALL、LC_<category> 和 LANG，比如 c 为 [CategoryTime] 时为 LC_TIME；
+//   - 平台相关，比如 linux 下 locale.conf 文件中的相同变量；
+//     windows 和 macOS 下，[CategoryMessages] 为界面语言(GetUserPreferredUILanguages 和 AppleLanguages 的第一个值)，
+//     其它分类为区域格式(GetUserDefaultLocaleName 和 AppleLocale)；
+//
+// 与 [DetectUserLanguage] 不同，不会读取 LANGUAGE 环境变量，
+// 即使 c 为 [CategoryMessages]，适用于时间、数值等格式化相关的场景。
+func DetectCategoryLanguage(c LocaleCategory) string { return defaultDetector.GetCategory(c) }
+
+// DetectCategoryLanguageTag 检测分类 c 的本地化信息
+//
+// 文档说明参考 [DetectCategoryLanguage]
+func DetectCategoryLanguageTag(c LocaleCategory) (language.Tag, error) {
+	return defaultDetector.CategoryTag(c)
+}
+
+// POSIXLocale POSIX 格式的本地化名称
+type POSIXLocale = syslocale.Locale
+
+// ParsePOSIXLocale 解析 POSIX 格式的本地化名称
+//
+// 格式为 language[_territory][.codeset][@modifier]，比如 zh_CN.UTF-8、sr_RS@latin 等：
+//   - 修饰符 latin、cyrillic 等会转换为对应的 script，比如 sr_RS@latin 转换为 sr-Latn-RS；
+//   - C、POSIX 以及 C.UTF-8 等转换为 [language.Und]；
+//   - 也支持 BCP 47 格式的值，比如 zh-Hans-CN；
+func ParsePOSIXLocale(s string) (*POSIXLocale, error) { return syslocale.Parse(s) }
+
+// InstalledLocales 返回系统中已生成的本地化信息
+//
+// 仅支持 linux，fsys 为系统的根目录，为空表示 os.DirFS("/")，依次读取以下内容：
+//   - /usr/lib/locale/locale-archive；
+//   - /usr/lib/locale 下包含 LC_IDENTIFICATION 文件的目录；
+//
+// 返回值的 [POSIXLocale.Tag] 为转换后的值，[POSIXLocale.Codeset] 为编码名称，
+// C、POSIX 等无法表示具体语言的值会被忽略。
+func InstalledLocales(fsys fs.FS) ([]*POSIXLocale, error) { return syslocale.Installed(fsys) }
+
+// SupportedLocales 返回系统支持但未必已经生成的本地化信息
+//
+// 仅支持 linux，读取 fsys 中的 /usr/share/i18n/SUPPORTED，
+// 其中未生成的本地化信息需要通过 localedef 或是 locale-gen 生成之后才能使用，
+// 已生成的本地化信息可通过 [InstalledLocales] 获取。
+func SupportedLocales(fsys fs.FS) ([]*POSIXLocale, error) { return syslocale.Supported(fsys) }
 
 // DetectUserLanguageTag 检测当前用户的本地化信息
 //
 // 文档说明参考 [DetectUserLanguage]
-func DetectUserLanguageTag() (language.Tag, error) {
-	return language.Parse(syslocale.Get())
-}
+func DetectUserLanguageTag() (language.Tag, error) { return defaultDetector.Tag() }
+
+// DetectUserLanguages 按优先级返回用户设置的所有语言
+//
+// 读取顺序与 [DetectUserLanguage] 相同，但是会返回所有来源中的值：
+//   - 环境变量 LANGUAGE 是以冒号分隔的列表，比如 zh_CN:zh_TW:en；
+//   - 平台相关的值，与 [DetectUserLanguage] 相同的区域格式在前，
+//     之后是首选语言列表，比如 macOS 下的 AppleLanguages，windows 下的 GetUserPreferredUILanguages 等；
+//   - LC_ALL、LC_MESSAGES 和 LANG 环境变量；
+//
+// 返回值已经去重，且忽略了无法解析的值，可直接用于 catalog.Catalog.Matcher 的匹配：
+//
+//	tag, _, _ := cat.Matcher().Match(localeutil.DetectUserLanguages()...)
+func DetectUserLanguages() []language.Tag { return defaultDetector.Tags() }
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func TestDetectUserLanguages(t *testing.T) {
+	a := assert.New(t, false)
+
+	t.Setenv("LANGUAGE", "zh_CN:zh-Hans-CN:en:invalid-tag-value")
+	t.Setenv("LC_ALL", "zh_CN.UTF-8")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	// 之后的值与当前系统的设置有关，仅检测由 LANGUAGE 决定的前几项。
+	tags := DetectUserLanguages()
+	a.True(len(tags) >= 3).
+		Equal(tags[:3], []language.Tag{language.MustParse("zh-CN"), language.MustParse("zh-Hans-CN"), language.English})
+
+	for i, tag := range tags {
+		for _, tag2 := range tags[i+1:] {
+			a.NotEqual(tag, tag2)
+		}
+	}
+
+	// 固定的来源
+	d := &Detector{Sources: []LocaleSource{SourceLanguage, SourceEnv}}
+	a.Equal(d.Tags(), []language.Tag{
+		language.MustParse("zh-CN"),
+		language.MustParse("zh-Hans-CN"),
+		language.English,
+		language.AmericanEnglish,
+	})
+}
+
+func TestParsePOSIXLocale(t *testing.T) {
+	a := assert.New(t, false)
+
+	l, err := ParsePOSIXLocale("sr_RS.UTF-8@latin")
+	a.NotError(err).
+		Equal(l.Tag, language.MustParse("sr-Latn-RS")).
+		Equal(l.Codeset, "UTF-8").
+		Equal(l.Modifier, "latin")
+
+	l, err = ParsePOSIXLocale("POSIX")
+	a.NotError(err).Equal(l.Tag, language.Und)
+
+	l, err = ParsePOSIXLocale("")
+	a.Error(err).Nil(l)
+}
+
+func TestDetectCategoryLanguage(t *testing.T) {
+	a := assert.New(t, false)
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MONETARY", "ja_JP.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	a.Equal(DetectCategoryLanguage(CategoryMonetary), "ja-JP")
+
+	tag, err := DetectCategoryLanguageTag(CategoryMonetary)
+	a.NotError(err).Equal(tag, language.MustParse("ja-JP"))
+}
+
+func TestInstalledLocales(t *testing.T) {
+	a := assert.New(t, false)
+
+	fsys := fstest.MapFS{
+		"usr/lib/locale/zh_CN.utf8/LC_IDENTIFICATION": &fstest.MapFile{},
+		"usr/share/i18n/SUPPORTED":                    &fstest.MapFile{Data: []byte("ja_JP.EUC-JP EUC-JP\n")},
+	}
+	ls, err := InstalledLocales(fsys)
+	a.NotError(err).Length(ls, 1).
+		Equal(ls[0].Tag, language.MustParse("zh-CN")).Equal(ls[0].Codeset, "utf8")
+
+	ls, err = SupportedLocales(fsys)
+	a.NotError(err).Length(ls, 1).
+		Equal(ls[0].Tag, language.MustParse("ja-JP")).Equal(ls[0].Codeset, "EUC-JP")
+}
@@ -35,9 +35,11 @@ type extractor struct {
 	funcs   []fn
 	root    string
 	tag     string
+	comment string
 
-	mux sync.Mutex
-	msg []message.Message
+	mux  sync.Mutex
+	file *message.File
+	keys map[string]int // 键名在 file.Messages 中的位置
 }
 
 // Extract 提取本地化内容
@@ -62,9 +64,13 @@ func Extract(ctx context.Context, o *Options) (*message.File, error) {
 		return nil, err
 	}
 
-	slices.SortStableFunc(ex.msg, func(a, b message.Message) int { return cmp.Compare(a.Key, b.Key) })
+	slices.SortStableFunc(ex.file.Messages, func(a, b message.Message) int { return cmp.Compare(a.Key, b.Key) })
+	for _, m := range ex.file.Messages { // 各个文件是并行处理的，需要保证 References 的顺序。
+		slices.SortFunc(m.References, compareReference)
+	}
+	ex.file.Languages = []language.Tag{o.Language}
 
-	return &message.File{Languages: []language.Tag{o.Language}, Messages: ex.msg}, nil
+	return ex.file, nil
 }
 
 func (ex *extractor) inspectDirs(ctx context.Context, dirs []string) error {
@@ -108,14 +114,15 @@ func (ex *extractor) inspectDir(ctx context.Context, dir string) error {
 			go func(f *ast.File) {
 				defer wg.Done()
 
+				comments := ex.comments(f)
 				ast.Inspect(f, func(n ast.Node) bool {
 					switch expr := n.(type) {
 					case *ast.ImportSpec:
 						return false
 					case *ast.CallExpr:
-						return ex.inspect(expr, info)
+						return ex.inspect(expr, info, comments)
 					case *ast.StructType:
-						ex.inspectStructTag(expr)
+						ex.inspectStructTag(expr, comments)
 						return true
 					default:
 						return true
@@ -128,7 +135,91 @@ func (ex *extractor) inspectDir(ctx context.Context, dir string) error {
 	return nil
 }
 
-func (ex *extractor) inspectStructTag(st *ast.StructType) {
+// 以 [Options.CommentTag] 开头的注释
+type comment struct {
+	text string // 去掉 [Options.CommentTag] 之后的内容
+
+	// 是否独占一行
+	//
+	// 只有独占一行的注释才能作为其下一行翻译项的注释，
+	// 否则像 f("a") // TRANSLATORS: x 这样的注释也会被当作下一行翻译项的注释。
+	standalone bool
+}
+
+// 返回 f 中以 [Options.CommentTag] 开头的注释
+//
+// 键名为注释结束的行号。
+func (ex *extractor) comments(f *ast.File) map[int]comment {
+	if ex.comment == "" {
+		return nil
+	}
+
+	// 每一行中第一个非注释节点的位置，用于判断注释是否独占一行。
+	first := make(map[int]token.Pos, 100)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil, *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+
+		for _, pos := range [...]token.Pos{n.Pos(), n.End() - 1} {
+			line := ex.fset.Position(pos).Line
+			if p, found := first[line]; !found || pos < p {
+				first[line] = pos
+			}
+		}
+		return true
+	})
+
+	comments := make(map[int]comment, len(f.Comments))
+	add := func(list []*ast.Comment, standalone bool) {
+		if len(list) == 0 {
+			return
+		}
+		text := strings.TrimSpace((&ast.CommentGroup{List: list}).Text())
+		if text, found := strings.CutPrefix(text, ex.comment); found {
+			line := ex.fset.Position(list[len(list)-1].End()).Line
+			comments[line] = comment{text: strings.TrimSpace(text), standalone: standalone}
+		}
+	}
+
+	// 同一个 ast.CommentGroup 中可能同时包含行尾注释和之后独占一行的注释，
+	// 需要将连续的独占一行的注释作为一个整体，行尾注释则单独处理。
+	for _, g := range f.Comments {
+		var block []*ast.Comment
+		for _, c := range g.List {
+			line := ex.fset.Position(c.Pos()).Line
+			if p, found := first[line]; found && p < c.Pos() { // 行尾注释
+				add(block, true)
+				block = nil
+				add([]*ast.Comment{c}, false)
+				continue
+			}
+
+			if len(block) > 0 && ex.fset.Position(block[len(block)-1].End()).Line+1 < line {
+				add(block, true)
+				block = nil
+			}
+			block = append(block, c)
+		}
+		add(block, true)
+	}
+
+	return comments
+}
+
+// 查找 p 所在行的注释或是其上一行独占一行的注释
+func lookupComment(comments map[int]comment, p token.Position) string {
+	if c, found := comments[p.Line]; found {
+		return c.text
+	}
+	if c, found := comments[p.Line-1]; found && c.standalone {
+		return c.text
+	}
+	return ""
+}
+
+func (ex *extractor) inspectStructTag(st *ast.StructType, comments map[int]comment) {
 	if ex.tag == "" {
 		return
 	}
@@ -141,7 +232,7 @@ func (ex *extractor) inspectStructTag(st *ast.StructType) {
 		val := f.Tag.Value
 		if tag := reflect.StructTag(val[1 : len(val)-1]).Get(ex.tag); tag != "" && tag != "-" {
 			p := ex.fset.Position(f.Pos())
-			ex.append(tag, p)
+			ex.append(tag, p, lookupComment(comments, p))
 		}
 	}
 }
@@ -149,7 +240,7 @@ func (ex *extractor) inspectStructTag(st *ast.StructType) {
 // 遍历 expr 表达式
 //
 // 返回值表示是否需要访问子元素
-func (ex *extractor) inspect(expr *ast.CallExpr, info *types.Info) bool {
+func (ex *extractor) inspect(expr *ast.CallExpr, info *types.Info, comments map[int]comment) bool {
 	t := info.TypeOf(expr.Fun)
 	switch typ := t.(type) {
 	case *types.Signature: // 所有 () 形式的调用
@@ -174,12 +265,12 @@ func (ex *extractor) inspect(expr *ast.CallExpr, info *types.Info) bool {
 
 		s := f.Signature()   // typ.Recv 永远返回 nil，只有通过 types.Func.Signature 返回的才会有正确的返回值
 		if s.Recv() == nil { // func
-			if !ex.tryAppendMsg(expr, f.Pkg().Path(), "", f.Name()) {
+			if !ex.tryAppendMsg(expr, comments, f.Pkg().Path(), "", f.Name()) {
 				return true
 			}
 		} else { // method
 			pkgName, structName := parseTypeName(s.Recv().Type().String())
-			if !ex.tryAppendMsg(expr, pkgName, structName, f.Name()) {
+			if !ex.tryAppendMsg(expr, comments, pkgName, structName, f.Name()) {
 				return true
 			}
 		}
@@ -196,12 +287,12 @@ func (ex *extractor) inspect(expr *ast.CallExpr, info *types.Info) bool {
 		}
 
 		pkgName, funcName := parseTypeName(rhs.String())
-		if !ex.tryAppendMsg(expr, pkgName, "", funcName) {
+		if !ex.tryAppendMsg(expr, comments, pkgName, "", funcName) {
 			return true
 		}
 	case *types.Named: // type X string; X('key')
 		obj := typ.Obj()
-		if !ex.tryAppendMsg(expr, obj.Pkg().Path(), "", obj.Name()) {
+		if !ex.tryAppendMsg(expr, comments, obj.Pkg().Path(), "", obj.Name()) {
 			return false
 		}
 	case *types.Basic:
@@ -210,7 +301,7 @@ func (ex *extractor) inspect(expr *ast.CallExpr, info *types.Info) bool {
 	return true
 }
 
-func (ex *extractor) tryAppendMsg(expr *ast.CallExpr, pkgName, structName, name string) (continueInspect bool) {
+func (ex *extractor) tryAppendMsg(expr *ast.CallExpr, comments map[int]comment, pkgName, structName, name string) (continueInspect bool) {
 	index := slices.IndexFunc(ex.funcs, func(m fn) bool {
 		return m.name == name && pkgName == m.pkgName && structName == m.typeName
 	})
@@ -218,11 +309,11 @@ func (ex *extractor) tryAppendMsg(expr *ast.CallExpr, pkgName, structName, name
 		return true
 	}
 
-	ex.appendMsg(expr)
+	ex.appendMsg(expr, comments)
 	return false
 }
 
-func (ex *extractor) appendMsg(expr *ast.CallExpr) {
+func (ex *extractor) appendMsg(expr *ast.CallExpr, comments map[int]comment) {
 	var key string
 	p := ex.fset.Position(expr.Pos())
 	path := ex.trimPath(p.Filename)
@@ -255,22 +346,58 @@ func (ex *extractor) appendMsg(expr *ast.CallExpr) {
 		return
 	}
 
-	ex.append(key, p)
+	ex.append(key, p, lookupComment(comments, p))
 }
 
-func (ex *extractor) append(key string, p token.Position) {
+// 添加翻译项
+//
+// comment 为开发者的注释，可以为空。
+// 如果 key 已经存在，仅将 p 和 comment 记录在已有的翻译项中。
+func (ex *extractor) append(key string, p token.Position, comment string) {
 	path := ex.trimPath(p.Filename)
+	ref := path + ":" + strconv.Itoa(p.Line)
 
 	ex.mux.Lock()
 	defer ex.mux.Unlock()
 
-	if slices.IndexFunc(ex.msg, func(m message.Message) bool { return m.Key == key }) >= 0 {
-		ex.warnLog(localeutil.Phrase("has same key %s at %s:%d, will be ignore", strconv.Quote(key), path, p.Line))
+	if i, found := ex.keys[key]; found {
+		m := &ex.file.Messages[i]
+		// 同一翻译项在多处使用是正常现象，仅记录其位置。
+		ex.infoLog(localeutil.Phrase("find same key %s at %s:%d, add it to the references", strconv.Quote(key), path, p.Line))
+
+		m.References = append(m.References, ref)
+		if comment != "" && !slices.Contains(m.Comments, comment) {
+			m.Comments = append(m.Comments, comment)
+		}
 		return
 	}
 
 	ex.infoLog(localeutil.Phrase("find new locale string %s at %s:%d", strconv.Quote(key), path, p.Line))
-	ex.msg = append(ex.msg, message.Message{Key: key, Message: message.Text{Msg: key}})
+	m := message.Message{Key: key, Message: message.Text{Msg: key}, References: []string{ref}}
+	if comment != "" {
+		m.Comments = []string{comment}
+	}
+	ex.keys[key] = len(ex.file.Messages)
+	ex.file.Messages = append(ex.file.Messages, m)
+}
+
+// 比较两个 file:line 格式的位置
+func compareReference(a, b string) int {
+	af, al := cutReference(a)
+	bf, bl := cutReference(b)
+	if c := cmp.Compare(af, bf); c != 0 {
+		return c
+	}
+	return cmp.Compare(al, bl)
+}
+
+func cutReference(ref string) (file string, line int) {
+	index := strings.LastIndexByte(ref, ':')
+	if index < 0 {
+		return ref, 0
+	}
+	line, _ = strconv.Atoi(ref[index+1:])
+	return ref[:index], line
 }
 
 func parseTypeName(t string) (pkg, structure string) {
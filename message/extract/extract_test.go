@@ -6,7 +6,10 @@ package extract
 
 import (
 	"context"
+	"go/parser"
+	"go/token"
 	"log"
+	"slices"
 	"testing"
 
 	"github.com/issue9/assert/v4"
@@ -148,3 +151,75 @@ func TestParseTypeName(t *testing.T) {
 	p, s = parseTypeName("github.com/issue9/abc.t[github.com/issue9/abc.Type]")
 	a.Equal(p, "github.com/issue9/abc").Equal(s, "t")
 }
+
+func TestExtractor_comments(t *testing.T) {
+	a := assert.New(t, false)
+
+	const src = `package p
+
+// TRANSLATORS: c1
+var _ = f("k1")
+
+// 普通注释
+var _ = f("k2")
+
+var _ = f("k3") // TRANSLATORS: c3
+var _ = f("k4")
+
+type S struct {
+	// TRANSLATORS: c5
+	F int ` + "`comment:\"k5\"`" + `
+}
+
+var _ = f("k6") // TRANSLATORS: c6
+// TRANSLATORS: c7
+// 多行
+var _ = f("k7")
+
+/* TRANSLATORS: c8 */ var _ = f("k8")
+`
+	ex := &extractor{fset: token.NewFileSet(), comment: "TRANSLATORS:"}
+	f, err := parser.ParseFile(ex.fset, "p.go", src, parser.ParseComments)
+	a.NotError(err).NotNil(f)
+
+	comments := ex.comments(f)
+	a.Equal(lookupComment(comments, token.Position{Line: 4}), "c1").
+		Empty(lookupComment(comments, token.Position{Line: 7})).
+		Equal(lookupComment(comments, token.Position{Line: 9}), "c3").
+		Empty(lookupComment(comments, token.Position{Line: 10})). // 上一行的行尾注释
+		Equal(lookupComment(comments, token.Position{Line: 14}), "c5").
+		Equal(lookupComment(comments, token.Position{Line: 17}), "c6").
+		Equal(lookupComment(comments, token.Position{Line: 20}), "c7\n多行").
+		Equal(lookupComment(comments, token.Position{Line: 22}), "c8")
+
+	ex.comment = ""
+	a.Nil(ex.comments(f))
+}
+
+func TestExtractor_append(t *testing.T) {
+	a := assert.New(t, false)
+
+	log := func(localeutil.Stringer) {}
+	ex := &extractor{
+		warnLog: log,
+		infoLog: log,
+		root:    "/root",
+		file:    &message.File{},
+		keys:    map[string]int{},
+	}
+
+	ex.append("k1", token.Position{Filename: "/root/a.go", Line: 10}, "c1")
+	ex.append("k1", token.Position{Filename: "/root/a.go", Line: 9}, "")
+	ex.append("k1", token.Position{Filename: "/root/b.go", Line: 1}, "c1")
+	ex.append("k2", token.Position{Filename: "/root/a.go", Line: 11}, "")
+
+	m, found := ex.file.Get("k1")
+	a.True(found).
+		Equal(m.Comments, []string{"c1"}).
+		Equal(m.References, []string{"a.go:10", "a.go:9", "b.go:1"})
+	slices.SortFunc(m.References, compareReference)
+	a.Equal(m.References, []string{"a.go:9", "a.go:10", "b.go:1"})
+
+	m, found = ex.file.Get("k2")
+	a.True(found).Nil(m.Comments).Equal(m.References, []string{"a.go:11"})
+}
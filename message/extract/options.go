@@ -76,6 +76,17 @@ type Options struct {
 
 	// 指定用于提取 struct tag 中的特定内容作为翻译项
 	Tag string
+
+	// 提取注释的前缀
+	//
+	// 位于翻译项的同一行或是上一行，且以此值开头的注释，
+	// 将去掉该前缀之后作为 [message.Message.Comments] 保存。
+	// 比如 CommentTag 为 TRANSLATORS: 时，以下注释将被提取为“登录的用户名”：
+	//  // TRANSLATORS: 登录的用户名
+	//  localeutil.Phrase("name")
+	//
+	// 如果为空，则不提取注释。
+	CommentTag string
 }
 
 // [Options.Funcs] 转换后的表示
@@ -105,9 +116,11 @@ func (o *Options) buildExtractor() (*extractor, error) {
 		fset:    token.NewFileSet(),
 		funcs:   split(o.Funcs...),
 		tag:     o.Tag,
+		comment: o.CommentTag,
 		root:    abs,
 
-		msg: make([]message.Message, 0, 100),
+		file: &message.File{Messages: make([]message.Message, 0, 100)},
+		keys: make(map[string]int, 100),
 	}, nil
 }
 
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package message
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"weak"
+)
+
+// 所有 [File] 对象的索引
+//
+// 索引并不保存在 [File] 中，这样即使 [File.Get] 等只读的方法更新了索引，
+// 也不会改变 [File] 的值，比如 [reflect.DeepEqual] 的比较结果；
+// 复制 [File] 得到的新对象也会有自己的索引。[File] 被回收之后，其索引也会被删除。
+var indexes sync.Map // 键名为 weak.Pointer[File]，键值为 *index
+
+// [File.Messages] 的索引
+type index struct {
+	mux sync.Mutex
+
+	keys map[string]int // 键名为 [Message.Key]，键值为在 msgs 中的位置
+
+	// 建立索引时的 [File.Messages]
+	//
+	// 如果 [File.Messages] 的长度或是底层数组发生了变化，说明索引已经过期。
+	msgs []Message
+}
+
+// 锁定并返回 f 的索引，使用完之后需要调用 index.mux.Unlock 解锁。
+func (f *File) lockIndex() *index {
+	p := weak.Make(f)
+	v, found := indexes.Load(p)
+	if !found {
+		if v, found = indexes.LoadOrStore(p, &index{}); !found {
+			runtime.AddCleanup(f, func(p weak.Pointer[File]) { indexes.Delete(p) }, p)
+		}
+	}
+
+	i := v.(*index)
+	i.mux.Lock()
+	return i
+}
+
+// 返回 key 在 f.Messages 中的位置，不存在返回 -1。
+//
+// 索引在第一次调用时建立，之后如果发现 f.Messages 被直接修改过，会重建索引。
+//
+// NOTE: 只有找到的元素才会验证其键名，如果 [Message.Key] 被直接修改，可能找不到新的键名。
+// 在批量操作之前调用 build 重建索引，之后的操作可以直接使用此方法；
+// 其它情况应该使用 [index.find]。
+func (i *index) indexOf(f *File, key string) int {
+	if i.keys == nil || i.stale(f.Messages) {
+		i.build(f)
+	}
+
+	n, found := i.keys[key]
+	if !found {
+		return -1
+	}
+	if f.Messages[n].Key != key { // 元素的位置被修改过，比如排序。
+		i.build(f)
+		if n, found = i.keys[key]; !found {
+			return -1
+		}
+	}
+	return n
+}
+
+// 与 indexOf 相同，但是在找不到时会遍历 f.Messages 进行确认，
+// 保证即使 [Message.Key] 被直接修改过，也能返回正确的结果。
+func (i *index) find(f *File, key string) int {
+	if n := i.indexOf(f, key); n >= 0 {
+		return n
+	}
+
+	n := slices.IndexFunc(f.Messages, func(m Message) bool { return m.Key == key })
+	if n >= 0 { // 索引已经过期
+		i.build(f)
+	}
+	return n
+}
+
+func (i *index) build(f *File) {
+	keys := make(map[string]int, len(f.Messages))
+	for n, m := range f.Messages {
+		if _, found := keys[m.Key]; !found { // 与 slices.IndexFunc 相同，以第一个为准。
+			keys[m.Key] = n
+		}
+	}
+	i.keys = keys
+	i.msgs = f.Messages
+}
+
+func (i *index) stale(msgs []Message) bool {
+	if len(msgs) != len(i.msgs) {
+		return true
+	}
+	return len(msgs) > 0 && &msgs[0] != &i.msgs[0]
+}
+
+// 添加新的元素并更新索引，调用者需要确保 m.Key 不存在于 f 中。
+func (i *index) append(f *File, m Message) {
+	i.indexOf(f, m.Key) // 确保索引是最新的
+
+	f.Messages = append(f.Messages, m)
+	i.keys[m.Key] = len(f.Messages) - 1
+	i.msgs = f.Messages
+}
+
+// Get 返回键名为 key 的元素
+//
+// 即使直接修改了 [File.Messages]，也能返回正确的结果，
+// 但是对于不存在的 key，需要遍历整个 [File.Messages] 进行确认。
+func (f *File) Get(key string) (Message, bool) {
+	i := f.lockIndex()
+	defer i.mux.Unlock()
+
+	if n := i.find(f, key); n >= 0 {
+		return f.Messages[n], true
+	}
+	return Message{}, false
+}
+
+// Set 添加或是修改元素
+//
+// 如果 m.Key 已经存在，则替换该元素，否则添加到 [File.Messages] 的末尾。
+func (f *File) Set(m Message) {
+	i := f.lockIndex()
+	defer i.mux.Unlock()
+
+	if n := i.find(f, m.Key); n >= 0 {
+		f.Messages[n] = m
+		return
+	}
+	i.append(f, m)
+}
+
+// Delete 删除键名为 key 的元素
+//
+// 返回值表示是否存在该元素。
+func (f *File) Delete(key string) bool {
+	i := f.lockIndex()
+	defer i.mux.Unlock()
+
+	n := i.find(f, key)
+	if n < 0 {
+		return false
+	}
+
+	f.Messages = slices.Delete(f.Messages, n, n+1)
+	i.build(f) // 之后的元素位置都已经改变
+	return true
+}
+
+// Keys 按 [File.Messages] 的顺序返回所有的键名
+func (f *File) Keys() []string {
+	keys := make([]string, 0, len(f.Messages))
+	for _, m := range f.Messages {
+		keys = append(keys, m.Key)
+	}
+	return keys
+}
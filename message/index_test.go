@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package message
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"weak"
+
+	"github.com/issue9/assert/v4"
+
+	"github.com/issue9/localeutil"
+)
+
+func TestFile_Get_Set_Delete(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := &File{Messages: []Message{{Key: "k1"}, {Key: "k2"}}}
+	a.Equal(f.Keys(), []string{"k1", "k2"})
+
+	m, found := f.Get("k2")
+	a.True(found).Equal(m.Key, "k2")
+	_, found = f.Get("k3")
+	a.False(found)
+
+	// 添加
+	f.Set(Message{Key: "k3", Message: Text{Msg: "v3"}})
+	m, found = f.Get("k3")
+	a.True(found).Equal(m.Message.Msg, "v3").
+		Equal(f.Keys(), []string{"k1", "k2", "k3"})
+
+	// 修改
+	f.Set(Message{Key: "k1", Message: Text{Msg: "v1"}})
+	m, found = f.Get("k1")
+	a.True(found).Equal(m.Message.Msg, "v1").
+		Equal(f.Keys(), []string{"k1", "k2", "k3"})
+
+	// 删除
+	a.True(f.Delete("k1")).
+		False(f.Delete("k1")).
+		Equal(f.Keys(), []string{"k2", "k3"})
+	m, found = f.Get("k3")
+	a.True(found).Equal(m.Message.Msg, "v3")
+
+	// 空对象
+	f = &File{}
+	_, found = f.Get("k1")
+	a.False(found).Empty(f.Keys())
+	f.Set(Message{Key: "k1"})
+	a.Equal(f.Keys(), []string{"k1"})
+}
+
+func TestFile_index(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := &File{Messages: make([]Message, 0, 10)}
+	f.Set(Message{Key: "k1"})
+	f.Set(Message{Key: "k2"})
+
+	// 直接修改 Messages
+	f.Messages = append(f.Messages, Message{Key: "k3"})
+	_, found := f.Get("k3")
+	a.True(found)
+
+	f.Messages = []Message{{Key: "k4"}, {Key: "k5"}, {Key: "k6"}}
+	_, found = f.Get("k1")
+	a.False(found)
+	_, found = f.Get("k5")
+	a.True(found)
+
+	// 排序
+	slices.Reverse(f.Messages)
+	m, found := f.Get("k4")
+	a.True(found).Equal(m.Key, "k4")
+	a.True(f.Delete("k6")).Equal(f.Keys(), []string{"k5", "k4"})
+
+	f.Messages = f.Messages[:0]
+	_, found = f.Get("k4")
+	a.False(found)
+
+	// 重复的键名，以第一个为准。
+	f.Messages = []Message{{Key: "k1", Message: Text{Msg: "1"}}, {Key: "k1", Message: Text{Msg: "2"}}}
+	m, found = f.Get("k1")
+	a.True(found).Equal(m.Message.Msg, "1")
+}
+
+func TestFile_index_editKey(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := &File{Messages: []Message{{Key: "k1"}, {Key: "k2"}}}
+	_, found := f.Get("k1")
+	a.True(found)
+
+	// 直接修改键名
+	f.Messages[0].Key = "k3"
+	m, found := f.Get("k3")
+	a.True(found).Equal(m.Key, "k3")
+	_, found = f.Get("k1")
+	a.False(found)
+
+	f.Messages[1].Key = "k4"
+	f.Set(Message{Key: "k4", Message: Text{Msg: "v4"}})
+	a.Equal(f.Keys(), []string{"k3", "k4"})
+	m, found = f.Get("k4")
+	a.True(found).Equal(m.Message.Msg, "v4")
+
+	f.Messages[0].Key = "k5"
+	a.True(f.Delete("k5")).Equal(f.Keys(), []string{"k4"})
+}
+
+func TestFile_MergeTo_concurrent(t *testing.T) {
+	a := assert.New(t, false)
+	log := func(localeutil.Stringer) {}
+
+	src := newBenchFile("k", 100)
+	wg := &sync.WaitGroup{}
+	dests := make([]*File, 10)
+	for i := range dests {
+		dests[i] = newBenchFile("n", 50)
+		wg.Add(1)
+		go func(dest *File) {
+			defer wg.Done()
+			src.MergeTo(log, dest, "dest.yaml")
+			_, found := dest.Get("k1")
+			a.True(found)
+		}(dests[i])
+	}
+	wg.Wait()
+
+	_, found := indexes.Load(weak.Make(src))
+	a.False(found)
+	for _, dest := range dests {
+		a.Equal(dest.Keys(), src.Keys())
+	}
+}
+
+func TestFile_Get_concurrent(t *testing.T) {
+	a := assert.New(t, false)
+
+	f := newBenchFile("k", 100)
+	cp := &File{Messages: slices.Clone(f.Messages)}
+	wg := &sync.WaitGroup{}
+	for i := range 10 {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			m, found := f.Get(key)
+			a.True(found).Equal(m.Key, key)
+		}("k" + strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	// 索引不影响 File 的值
+	a.Equal(f, cp)
+}
+
+func newBenchFile(prefix string, size int) *File {
+	f := &File{Messages: make([]Message, 0, size)}
+	for i := range size {
+		key := prefix + strconv.Itoa(i)
+		f.Messages = append(f.Messages, Message{Key: key, Message: Text{Msg: key}})
+	}
+	return f
+}
+
+func BenchmarkFile_Join(b *testing.B) {
+	const size = 40000
+	src := newBenchFile("k", size)
+	l2 := newBenchFile("k", size) // 一半相同的键名
+	l2.Messages = append(l2.Messages[size/2:], newBenchFile("n", size/2).Messages...)
+
+	for b.Loop() {
+		l := &File{Messages: slices.Clone(src.Messages)}
+		l.Join(l2)
+	}
+}
+
+func BenchmarkFile_MergeTo(b *testing.B) {
+	const size = 40000
+	src := newBenchFile("k", size)
+	log := func(localeutil.Stringer) {}
+
+	for b.Loop() {
+		dest := newBenchFile("k", size/2)
+		dest.Messages = append(dest.Messages, newBenchFile("n", size/2).Messages...)
+		src.MergeTo(log, dest, "dest.yaml")
+	}
+}
+
+func BenchmarkFile_Get(b *testing.B) {
+	const size = 40000
+	f := newBenchFile("k", size)
+	keys := f.Keys()
+
+	for b.Loop() {
+		for _, key := range keys {
+			f.Get(key)
+		}
+	}
+}
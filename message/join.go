@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package message
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/issue9/localeutil"
+)
+
+type (
+	// JoinStrategy 处理 [File.JoinWith] 中键名冲突的策略
+	//
+	// l 和 l2 分别为两个 [File] 中键名相同但内容不同的元素，返回值为最终写入的元素。
+	// 如果返回错误，[File.JoinWith] 将放弃所有的修改并返回该错误。
+	JoinStrategy = func(l, l2 Message) (Message, error)
+
+	// Conflict 合并时键名相同但内容不同的元素
+	Conflict struct {
+		Key   string
+		Left  Message // 原有的元素
+		Right Message // 参数中的元素
+	}
+)
+
+// PreferLeft 冲突时保留原有的元素
+func PreferLeft(l, _ Message) (Message, error) { return l, nil }
+
+// PreferRight 冲突时采用参数中的元素
+func PreferRight(_, l2 Message) (Message, error) { return l2, nil }
+
+// ErrorOnConflict 冲突时返回错误
+func ErrorOnConflict(l, _ Message) (Message, error) {
+	return Message{}, localeutil.Error("the key %s has conflicting values", strconv.Quote(l.Key))
+}
+
+// JoinWith 将 l2.Messages 并入 l.Messages
+//
+// l2 中不存在于 l 的元素直接写入 l，键名相同但 [Message.Message] 不同的元素由 s 决定最终的值，
+// s 为空表示 [PreferRight]。
+// 无论采用哪个元素，两者的 [Message.Comments]、[Message.TranslatorComments] 和 [Message.References] 都会被合并，
+// [Message.Flags] 如果为空，也会采用另一个元素的值。
+//
+// 返回所有冲突的元素；如果 s 返回了错误，则 l 不会有任何改变，返回值中仅包含截止到出错时的冲突。
+func (l *File) JoinWith(l2 *File, s JoinStrategy) ([]*Conflict, error) {
+	if s == nil {
+		s = PreferRight
+	}
+
+	i := l.lockIndex()
+	defer i.mux.Unlock()
+
+	i.build(l)
+	var conflicts []*Conflict
+	sets := make(map[int]Message, len(l2.Messages))
+	for _, m2 := range l2.Messages {
+		n := i.indexOf(l, m2.Key)
+		if n < 0 {
+			continue
+		}
+
+		m := l.Messages[n]
+		v := m
+		if !reflect.DeepEqual(m.Message, m2.Message) {
+			conflicts = append(conflicts, &Conflict{Key: m2.Key, Left: m, Right: m2})
+
+			var err error
+			if v, err = s(m, m2); err != nil {
+				return conflicts, err
+			}
+			v.Key = m.Key // 策略不能修改键名
+		}
+		v.joinMeta(m)
+		v.joinMeta(m2)
+
+		if !reflect.DeepEqual(v, m) {
+			sets[n] = v
+		}
+	}
+
+	for n, m := range sets {
+		l.Messages[n] = m
+	}
+	for _, m2 := range l2.Messages {
+		if i.indexOf(l, m2.Key) < 0 {
+			i.append(l, m2)
+		}
+	}
+
+	return conflicts, nil
+}
+
+// 将 m2 中的辅助信息合并到 m
+func (m *Message) joinMeta(m2 Message) {
+	m.Comments = joinStrings(m.Comments, m2.Comments)
+	m.TranslatorComments = joinStrings(m.TranslatorComments, m2.TranslatorComments)
+	m.References = joinStrings(m.References, m2.References)
+	if m.Flags == nil {
+		m.Flags = m2.Flags
+	}
+}
+
+// 将 s2 中不存在于 s 的元素添加到 s 的末尾
+func joinStrings(s, s2 []string) []string {
+	s = slices.Clip(s) // 防止修改与其它元素共用的底层数组
+	for _, v := range s2 {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
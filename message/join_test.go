@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package message
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+func newJoinFiles() (*File, *File) {
+	l := &File{Messages: []Message{
+		{Key: "l"},
+		{Key: "same", Message: Text{Msg: "same"}},
+		{Key: "g1", Message: Text{Msg: "l1"}},
+		{Key: "g2", Message: Text{Msg: "l2"}},
+	}}
+	l2 := &File{Messages: []Message{
+		{Key: "r"},
+		{Key: "same", Message: Text{Msg: "same"}},
+		{Key: "g1", Message: Text{Msg: "r1"}},
+		{Key: "g2", Message: Text{Msg: "r2"}},
+	}}
+	return l, l2
+}
+
+func TestFile_JoinWith(t *testing.T) {
+	a := assert.New(t, false)
+
+	l, l2 := newJoinFiles()
+	conflicts, err := l.JoinWith(l2, PreferLeft)
+	a.NotError(err).Length(conflicts, 2).
+		Equal(conflicts[0], &Conflict{Key: "g1", Left: Message{Key: "g1", Message: Text{Msg: "l1"}}, Right: Message{Key: "g1", Message: Text{Msg: "r1"}}}).
+		Equal(conflicts[1].Key, "g2")
+	a.Equal(l.Keys(), []string{"l", "same", "g1", "g2", "r"})
+	m, found := l.Get("g1")
+	a.True(found).Equal(m.Message.Msg, "l1")
+
+	l, l2 = newJoinFiles()
+	conflicts, err = l.JoinWith(l2, PreferRight)
+	a.NotError(err).Length(conflicts, 2)
+	a.Equal(l.Keys(), []string{"l", "same", "g1", "g2", "r"})
+	m, found = l.Get("g2")
+	a.True(found).Equal(m.Message.Msg, "r2")
+
+	// nil 即 PreferRight
+	l, l2 = newJoinFiles()
+	conflicts, err = l.JoinWith(l2, nil)
+	a.NotError(err).Length(conflicts, 2)
+	m, found = l.Get("g1")
+	a.True(found).Equal(m.Message.Msg, "r1")
+
+	// ErrorOnConflict 不会修改 l
+	l, l2 = newJoinFiles()
+	conflicts, err = l.JoinWith(l2, ErrorOnConflict)
+	a.Error(err).Length(conflicts, 1).Equal(conflicts[0].Key, "g1")
+	a.Equal(l.Keys(), []string{"l", "same", "g1", "g2"})
+	m, found = l.Get("g1")
+	a.True(found).Equal(m.Message.Msg, "l1")
+
+	// 自定义
+	l, l2 = newJoinFiles()
+	conflicts, err = l.JoinWith(l2, func(m, m2 Message) (Message, error) {
+		return Message{Key: "changed", Message: Text{Msg: m.Message.Msg + m2.Message.Msg}}, nil
+	})
+	a.NotError(err).Length(conflicts, 2)
+	m, found = l.Get("g1")
+	a.True(found).Equal(m.Message.Msg, "l1r1")
+	_, found = l.Get("changed")
+	a.False(found)
+}
+
+func TestFile_JoinWith_meta(t *testing.T) {
+	a := assert.New(t, false)
+
+	l := &File{Messages: []Message{
+		{Key: "k1", Message: Text{Msg: "l"}, Comments: []string{"c1"}, References: []string{"a.go:1"}},
+		{Key: "k2", Message: Text{Msg: "same"}, TranslatorComments: []string{"t1"}, Flags: &Flags{Fuzzy: true}},
+	}}
+	l2 := &File{Messages: []Message{
+		{Key: "k1", Message: Text{Msg: "r"}, References: []string{"a.go:1", "b.go:2"}, Flags: &Flags{MaxLength: 5}},
+		{Key: "k2", Message: Text{Msg: "same"}, TranslatorComments: []string{"t2"}, Flags: &Flags{NoFormat: true}},
+	}}
+
+	conflicts, err := l.JoinWith(l2, PreferRight)
+	a.NotError(err).Length(conflicts, 1).Equal(conflicts[0].Key, "k1")
+
+	a.Equal(l.Messages[0], Message{
+		Key:        "k1",
+		Message:    Text{Msg: "r"},
+		Comments:   []string{"c1"},
+		References: []string{"a.go:1", "b.go:2"},
+		Flags:      &Flags{MaxLength: 5},
+	})
+	a.Equal(l.Messages[1], Message{
+		Key:                "k2",
+		Message:            Text{Msg: "same"},
+		TranslatorComments: []string{"t1", "t2"},
+		Flags:              &Flags{Fuzzy: true},
+	})
+	a.Equal(l2.Messages[0].References, []string{"a.go:1", "b.go:2"}) // 不会修改 l2
+}
@@ -9,7 +9,6 @@ import (
 	"slices"
 	"strconv"
 
-	"github.com/issue9/sliceutil"
 	"golang.org/x/text/feature/plural"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message/catalog"
@@ -19,6 +18,12 @@ import (
 
 type (
 	// File 单个本地化语言组成的文件
+	//
+	// File 有一个键名的索引，但是该索引并不保存在 File 中，
+	// 所以 [File.Get] 等只读的方法不会改变 File 的值，且可以在多个 goroutine 中同时调用；
+	// 与其它对象一样，修改 File 的方法则不能与其它方法同时调用。
+	// [File.MergeTo] 和 [File.JoinWith] 不会修改作为数据源的对象，
+	// 可以将同一个对象并发地合并到不同的对象中。
 	File struct {
 		XMLName   struct{}       `xml:"language" json:"-" yaml:"-" toml:"-"`
 		Languages []language.Tag `xml:"languages>language" json:"languages" yaml:"languages" toml:"languages"` // 如果用字符串，还需要处理大小写以及不同值表示同一个 language.Tag 对象的问题
@@ -29,6 +34,20 @@ type (
 	Message struct {
 		Key     string `xml:"key" json:"key" yaml:"key" toml:"key"`
 		Message Text   `xml:"message" json:"message" yaml:"message" toml:"message"`
+
+		// 以下为提供给翻译人员的辅助信息，不影响翻译的结果。
+
+		Comments           []string `xml:"comment,omitempty" json:"comments,omitempty" yaml:"comments,omitempty" toml:"comments,omitempty"`                                         // 开发者的注释，一般由 message/extract 从源码中提取
+		TranslatorComments []string `xml:"translatorComment,omitempty" json:"translatorComments,omitempty" yaml:"translatorComments,omitempty" toml:"translatorComments,omitempty"` // 翻译人员的注释
+		References         []string `xml:"reference,omitempty" json:"references,omitempty" yaml:"references,omitempty" toml:"references,omitempty"`                                 // 在源码中的位置，格式为 file:line
+		Flags              *Flags   `xml:"flags,omitempty" json:"flags,omitempty" yaml:"flags,omitempty" toml:"flags,omitempty"`
+	}
+
+	// Flags 翻译项的标记
+	Flags struct {
+		Fuzzy     bool `xml:"fuzzy,attr,omitempty" json:"fuzzy,omitempty" yaml:"fuzzy,omitempty" toml:"fuzzy,omitempty"`                 // 翻译内容有待确认
+		NoFormat  bool `xml:"noFormat,attr,omitempty" json:"noFormat,omitempty" yaml:"noFormat,omitempty" toml:"noFormat,omitempty"`     // [Message.Key] 中的 % 不是格式化占位符
+		MaxLength int  `xml:"maxLength,attr,omitempty" json:"maxLength,omitempty" yaml:"maxLength,omitempty" toml:"maxLength,omitempty"` // 翻译内容的最大长度，0 表示不限制
 	}
 
 	Text struct {
@@ -64,15 +83,10 @@ type (
 //
 //	-如果 l2 的 [Message.Key] 存在于 l，则覆盖 l 的项；
 //	-如果 l2 的 [Message.Key] 不存在于 l，则写入 l；
+//
+// 如果需要其它的冲突处理方式，可以使用 [File.JoinWith]。
 func (l *File) Join(l2 *File) {
-	for index, m2 := range l2.Messages {
-		elem, found := sliceutil.At(l.Messages, func(m1 Message, _ int) bool { return m1.Key == m2.Key })
-		if !found {
-			l.Messages = append(l.Messages, m2)
-		} else {
-			l2.Messages[index] = elem
-		}
-	}
+	l.JoinWith(l2, PreferRight) // PreferRight 不会返回错误
 }
 
 // Merge 将 l.Messages 写入 dest
@@ -81,24 +95,45 @@ func (l *File) Join(l2 *File) {
 //
 //	-删除只存在于 dest 元素中而不存在于 l 的内容；
 //	-将 l 独有的项写入 dest；
+//	-同时存在的项，以 l 的 [Message.Comments] 和 [Message.References] 为准，
+//	 [Message.TranslatorComments] 和 [Message.Flags] 则保留 dest 中的值；
 //
 // 最终内容是 dest 为准。
 // log 所有删除的记录都将通过此输出；
 // destFile 最终输出的文件名，该值仅在错误信息中；
 func (f *File) MergeTo(log LogFunc, dest *File, destFile string) {
+	// 仅需判断键名是否存在，不需要 f 的索引。
+	keys := make(map[string]struct{}, len(f.Messages))
+	for _, m := range f.Messages {
+		keys[m.Key] = struct{}{}
+	}
+
 	// 删除只存在于 dest 而不存在于 l 的内容
-	dest.Messages = sliceutil.Delete(dest.Messages, func(dm Message, _ int) bool {
-		exist := slices.IndexFunc(f.Messages, func(sm Message) bool { return sm.Key == dm.Key }) >= 0
+	dest.Messages = slices.DeleteFunc(dest.Messages, func(dm Message) bool {
+		_, exist := keys[dm.Key]
 		if !exist {
 			log(localeutil.Phrase("the key %s of %s not found, will be deleted", strconv.Quote(dm.Key), destFile))
 		}
 		return !exist
 	})
 
-	// 将 l 独有的项写入 dest
+	i := dest.lockIndex()
+	defer i.mux.Unlock()
+
+	i.build(dest)
 	for _, sm := range f.Messages {
-		if slices.IndexFunc(dest.Messages, func(dm Message) bool { return dm.Key == sm.Key }) < 0 {
-			dest.Messages = append(dest.Messages, sm)
+		n := i.indexOf(dest, sm.Key)
+		if n < 0 { // 将 l 独有的项写入 dest
+			i.append(dest, sm)
+			continue
+		}
+
+		dm := &dest.Messages[n]
+		dm.Comments = slices.Clone(sm.Comments) // 防止 dest 与 f 共用底层数组
+		dm.References = slices.Clone(sm.References)
+		if dm.Flags == nil && sm.Flags != nil {
+			flags := *sm.Flags
+			dm.Flags = &flags
 		}
 	}
 }
@@ -5,6 +5,8 @@
 package message
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"testing"
 
 	"github.com/issue9/assert/v4"
@@ -27,7 +29,10 @@ func TestLanguage_Join(t *testing.T) {
 		Messages:  []Message{{Key: "l"}, {Key: "g", Message: Text{Msg: "l"}}},
 	}
 	l.Join(src)
-	a.Length(l.Messages, 3)
+	a.Length(l.Messages, 3).
+		Equal(l.Messages[1].Message.Msg, "src").
+		Equal(l.Messages[2].Key, "src").
+		Equal(src.Messages[1].Message.Msg, "src")
 }
 
 func TestLanguage_MergeTo(t *testing.T) {
@@ -60,6 +65,70 @@ func TestLanguage_MergeTo(t *testing.T) {
 		Length(l.Messages, 2).Equal(l.Messages[0].Key, "l").Equal(l.Messages[1].Key, "g")
 }
 
+func TestLanguage_MergeTo_meta(t *testing.T) {
+	a := assert.New(t, false)
+	log := func(s localeutil.Stringer) {}
+
+	dest := &File{
+		Languages: []language.Tag{language.SimplifiedChinese},
+		Messages: []Message{
+			{Key: "k1", Message: Text{Msg: "m1"}, Comments: []string{"old"}, References: []string{"a.go:1"}, TranslatorComments: []string{"t1"}, Flags: &Flags{Fuzzy: true}},
+			{Key: "k2", Message: Text{Msg: "m2"}},
+		},
+	}
+	src := &File{
+		Messages: []Message{
+			{Key: "k1", Message: Text{Msg: "k1"}, Comments: []string{"new"}, References: []string{"a.go:2"}, Flags: &Flags{NoFormat: true}},
+			{Key: "k2", Message: Text{Msg: "k2"}, Flags: &Flags{MaxLength: 5}},
+		},
+	}
+	src.MergeTo(log, dest, "dest.yaml")
+	a.Equal(dest.Messages[0], Message{
+		Key:                "k1",
+		Message:            Text{Msg: "m1"},
+		Comments:           []string{"new"},
+		References:         []string{"a.go:2"},
+		TranslatorComments: []string{"t1"},
+		Flags:              &Flags{Fuzzy: true},
+	}).Equal(dest.Messages[1], Message{
+		Key:     "k2",
+		Message: Text{Msg: "m2"},
+		Flags:   &Flags{MaxLength: 5},
+	})
+
+	// 不与 src 共用底层数组
+	dest.Messages[0].References[0] = "b.go:1"
+	a.Equal(src.Messages[0].References, []string{"a.go:2"})
+}
+
+func TestMessage_marshal(t *testing.T) {
+	a := assert.New(t, false)
+
+	m := Message{Key: "k1", Message: Text{Msg: "m1"}}
+	data, err := json.Marshal(m)
+	a.NotError(err).Equal(string(data), `{"key":"k1","message":{"msg":"m1"}}`)
+	data, err = xml.Marshal(m)
+	a.NotError(err).Equal(string(data), `<Message><key>k1</key><message><msg>m1</msg></message></Message>`)
+
+	m = Message{
+		Key:                "k1",
+		Message:            Text{Msg: "m1"},
+		Comments:           []string{"c1", "c2"},
+		TranslatorComments: []string{"t1"},
+		References:         []string{"a.go:1"},
+		Flags:              &Flags{Fuzzy: true, MaxLength: 5},
+	}
+	data, err = json.Marshal(m)
+	a.NotError(err).Equal(string(data), `{"key":"k1","message":{"msg":"m1"},"comments":["c1","c2"],"translatorComments":["t1"],"references":["a.go:1"],"flags":{"fuzzy":true,"maxLength":5}}`)
+	m2 := Message{}
+	a.NotError(json.Unmarshal(data, &m2)).Equal(m2, m)
+
+	data, err = xml.Marshal(m)
+	a.NotError(err).Equal(string(data), `<Message><key>k1</key><message><msg>m1</msg></message><comment>c1</comment><comment>c2</comment><translatorComment>t1</translatorComment><reference>a.go:1</reference><flags fuzzy="true" maxLength="5"></flags></Message>`)
+	m2 = Message{}
+	a.NotError(xml.Unmarshal(data, &m2)).Equal(m2, m)
+}
+
 func TestLanguage_Catalog(t *testing.T) {
 	a := assert.New(t, false)
 
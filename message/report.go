@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+type (
+	// Report 翻译进度报告
+	Report struct {
+		Keys      int               `json:"keys"`  // 源文件中的翻译项数量
+		Words     int               `json:"words"` // 源文件的单词数
+		Chars     int               `json:"chars"` // 源文件的字符数
+		Languages []*LanguageReport `json:"languages"`
+	}
+
+	// LanguageReport 单个翻译文件的进度报告
+	//
+	// 所有的百分比均是相对于源文件中翻译项的数量。
+	LanguageReport struct {
+		Languages []language.Tag `json:"languages"`
+
+		Translated        int     `json:"translated"`
+		TranslatedPercent float64 `json:"translatedPercent"`
+
+		// 翻译内容与 [Message.Key] 相同或是为空的项
+		Untranslated        int     `json:"untranslated"`
+		UntranslatedPercent float64 `json:"untranslatedPercent"`
+
+		// 存在于源文件但是不存在于翻译文件的项
+		Missing        int     `json:"missing"`
+		MissingPercent float64 `json:"missingPercent"`
+
+		// 存在于翻译文件但是不存在于源文件的项
+		Obsolete        int     `json:"obsolete"`
+		ObsoletePercent float64 `json:"obsoletePercent"`
+
+		// 待翻译内容的单词数和字符数
+		//
+		// 包含 Untranslated 和 Missing 两部分，可用于估算翻译的工作量。
+		Words int `json:"words"`
+		Chars int `json:"chars"`
+	}
+)
+
+// NewReport 统计 files 相对于 src 的翻译进度
+//
+// src 为源文件，一般为 message/extract 提取的内容；
+// files 为翻译后的文件，比如 message/serialize.LoadGlob 的返回值；
+func NewReport(src *File, files ...*File) *Report {
+	r := &Report{
+		Keys:      len(src.Messages),
+		Languages: make([]*LanguageReport, 0, len(files)),
+	}
+
+	srcTexts := make(map[string]string, len(src.Messages))
+	for _, m := range src.Messages {
+		txt := m.Message.text()
+		srcTexts[m.Key] = txt
+
+		w, c := countWords(txt)
+		r.Words += w
+		r.Chars += c
+	}
+
+	for _, f := range files {
+		lr := &LanguageReport{Languages: f.Languages}
+
+		exists := make(map[string]struct{}, len(f.Messages))
+		for _, m := range f.Messages {
+			exists[m.Key] = struct{}{}
+
+			txt, found := srcTexts[m.Key]
+			switch {
+			case !found:
+				lr.Obsolete++
+			case m.untranslated():
+				lr.Untranslated++
+				w, c := countWords(txt)
+				lr.Words += w
+				lr.Chars += c
+			default:
+				lr.Translated++
+			}
+		}
+
+		for _, m := range src.Messages {
+			if _, found := exists[m.Key]; !found {
+				lr.Missing++
+				w, c := countWords(srcTexts[m.Key])
+				lr.Words += w
+				lr.Chars += c
+			}
+		}
+
+		lr.TranslatedPercent = percent(lr.Translated, r.Keys)
+		lr.UntranslatedPercent = percent(lr.Untranslated, r.Keys)
+		lr.MissingPercent = percent(lr.Missing, r.Keys)
+		lr.ObsoletePercent = percent(lr.Obsolete, r.Keys)
+
+		r.Languages = append(r.Languages, lr)
+	}
+
+	return r
+}
+
+// Text 以纯文本的形式输出报告
+func (r *Report) Text(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "source: %d keys, %d words, %d chars\n", r.Keys, r.Words, r.Chars)
+
+	for _, lr := range r.Languages {
+		fmt.Fprintf(b, "\n%s\n", joinTags(lr.Languages))
+		fmt.Fprintf(b, "  translated:   %d (%.2f%%)\n", lr.Translated, lr.TranslatedPercent)
+		fmt.Fprintf(b, "  untranslated: %d (%.2f%%)\n", lr.Untranslated, lr.UntranslatedPercent)
+		fmt.Fprintf(b, "  missing:      %d (%.2f%%)\n", lr.Missing, lr.MissingPercent)
+		fmt.Fprintf(b, "  obsolete:     %d (%.2f%%)\n", lr.Obsolete, lr.ObsoletePercent)
+		fmt.Fprintf(b, "  remaining:    %d words, %d chars\n", lr.Words, lr.Chars)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Markdown 以 markdown 表格的形式输出报告
+func (r *Report) Markdown(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "source: %d keys, %d words, %d chars\n\n", r.Keys, r.Words, r.Chars)
+
+	b.WriteString("| languages | translated | untranslated | missing | obsolete | words | chars |\n")
+	b.WriteString("|-----------|-----------:|-------------:|--------:|---------:|------:|------:|\n")
+	for _, lr := range r.Languages {
+		fmt.Fprintf(b, "| %s | %d (%.2f%%) | %d (%.2f%%) | %d (%.2f%%) | %d (%.2f%%) | %d | %d |\n",
+			joinTags(lr.Languages),
+			lr.Translated, lr.TranslatedPercent,
+			lr.Untranslated, lr.UntranslatedPercent,
+			lr.Missing, lr.MissingPercent,
+			lr.Obsolete, lr.ObsoletePercent,
+			lr.Words, lr.Chars,
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// JSON 以 JSON 的形式输出报告
+func (r *Report) JSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "    ")
+	return e.Encode(r)
+}
+
+// 是否为未翻译的内容
+func (m *Message) untranslated() bool {
+	if m.Message.Select != nil || len(m.Message.Vars) > 0 {
+		return false
+	}
+	return m.Message.Msg == "" || m.Message.Msg == m.Key
+}
+
+// 返回所有可显示的文本内容
+func (t *Text) text() string {
+	b := &strings.Builder{}
+	b.WriteString(t.Msg)
+
+	if t.Select != nil {
+		for _, c := range t.Select.Cases {
+			b.WriteByte(' ')
+			b.WriteString(c.Value)
+		}
+	}
+
+	for _, v := range t.Vars {
+		for _, c := range v.Cases {
+			b.WriteByte(' ')
+			b.WriteString(c.Value)
+		}
+	}
+
+	return b.String()
+}
+
+// 统计 s 中的单词数和字符数
+//
+// 以空白字符分隔单词，中日文的每个字符都单独作为一个单词计算；
+// 字符数不包含空白字符。
+func countWords(s string) (words, chars int) {
+	inWord := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			inWord = false
+			continue
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			words++
+			inWord = false
+		case !inWord:
+			words++
+			inWord = true
+		}
+		chars++
+	}
+	return words, chars
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+func joinTags(tags []language.Tag) string {
+	strs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		strs = append(strs, tag.String())
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func TestNewReport(t *testing.T) {
+	a := assert.New(t, false)
+
+	src := &File{
+		Languages: []language.Tag{language.Und},
+		Messages: []Message{
+			{Key: "k1", Message: Text{Msg: "hello world"}},
+			{Key: "k2", Message: Text{Msg: "k2"}},
+			{Key: "k3", Message: Text{Msg: "汉字 abc"}},
+			{Key: "k4", Message: Text{Select: &Select{Cases: []*Case{{Case: "=1", Value: "one"}, {Case: "other", Value: "more"}}}}},
+		},
+	}
+
+	cn := &File{
+		Languages: []language.Tag{language.SimplifiedChinese, language.MustParse("cmn-Hans")},
+		Messages: []Message{
+			{Key: "k1", Message: Text{Msg: "你好世界"}},
+			{Key: "k2", Message: Text{Msg: "k2"}},
+			{Key: "k5", Message: Text{Msg: "k5"}},
+		},
+	}
+
+	tw := &File{
+		Languages: []language.Tag{language.TraditionalChinese},
+		Messages: []Message{
+			{Key: "k1", Message: Text{Msg: "你好世界"}},
+			{Key: "k2", Message: Text{Msg: "k2"}},
+			{Key: "k3", Message: Text{Msg: ""}},
+			{Key: "k4", Message: Text{Select: &Select{Cases: []*Case{{Case: "other", Value: "多"}}}}},
+		},
+	}
+
+	r := NewReport(src, cn, tw)
+	a.Equal(r.Keys, 4).
+		Equal(r.Words, 8).
+		Equal(r.Chars, 24).
+		Length(r.Languages, 2)
+
+	lr := r.Languages[0]
+	a.Equal(lr.Languages, cn.Languages).
+		Equal(lr.Translated, 1).Equal(lr.TranslatedPercent, 25).
+		Equal(lr.Untranslated, 1).Equal(lr.UntranslatedPercent, 25).
+		Equal(lr.Missing, 2).Equal(lr.MissingPercent, 50).
+		Equal(lr.Obsolete, 1).Equal(lr.ObsoletePercent, 25).
+		Equal(lr.Words, 6). // k2 + k3 + k4
+		Equal(lr.Chars, 14)
+
+	lr = r.Languages[1]
+	a.Equal(lr.Translated, 2).
+		Equal(lr.Untranslated, 2).
+		Equal(lr.Missing, 0).
+		Equal(lr.Obsolete, 0).
+		Equal(lr.Words, 4).
+		Equal(lr.Chars, 7)
+
+	r = NewReport(&File{})
+	a.Equal(r.Keys, 0).Empty(r.Languages)
+}
+
+func TestReport_output(t *testing.T) {
+	a := assert.New(t, false)
+
+	src := &File{Messages: []Message{{Key: "k1", Message: Text{Msg: "k1"}}, {Key: "k2", Message: Text{Msg: "k2"}}}}
+	cn := &File{
+		Languages: []language.Tag{language.SimplifiedChinese},
+		Messages:  []Message{{Key: "k1", Message: Text{Msg: "v1"}}},
+	}
+	r := NewReport(src, cn)
+
+	buf := &bytes.Buffer{}
+	a.NotError(r.Text(buf))
+	a.Equal(buf.String(), `source: 2 keys, 2 words, 4 chars
+
+zh-Hans
+  translated:   1 (50.00%)
+  untranslated: 0 (0.00%)
+  missing:      1 (50.00%)
+  obsolete:     0 (0.00%)
+  remaining:    1 words, 2 chars
+`)
+
+	buf.Reset()
+	a.NotError(r.Markdown(buf))
+	a.Equal(buf.String(), `source: 2 keys, 2 words, 4 chars
+
+| languages | translated | untranslated | missing | obsolete | words | chars |
+|-----------|-----------:|-------------:|--------:|---------:|------:|------:|
+| zh-Hans | 1 (50.00%) | 0 (0.00%) | 1 (50.00%) | 0 (0.00%) | 1 | 2 |
+`)
+
+	buf.Reset()
+	a.NotError(r.JSON(buf))
+	r2 := &Report{}
+	a.NotError(json.Unmarshal(buf.Bytes(), r2)).
+		Equal(r2.Keys, 2).
+		Length(r2.Languages, 1).
+		Equal(r2.Languages[0].Languages, []language.Tag{language.SimplifiedChinese}).
+		Equal(r2.Languages[0].MissingPercent, 50)
+}
+
+func TestCountWords(t *testing.T) {
+	a := assert.New(t, false)
+
+	w, c := countWords("")
+	a.Equal(w, 0).Equal(c, 0)
+
+	w, c = countWords(" hello,  world ")
+	a.Equal(w, 2).Equal(c, 11)
+
+	w, c = countWords("汉字abc def")
+	a.Equal(w, 4).Equal(c, 8)
+
+	w, c = countWords("ひらがな")
+	a.Equal(w, 4).Equal(c, 4)
+}
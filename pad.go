@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type aligned struct {
+	wo WidthOptions
+	v  any
+}
+
+// PadLeft 在 s 的左侧填充 fill 使其宽度达到 w
+//
+// 即右对齐。如果 s 的宽度已经不小于 w，则原样返回。
+// fill 的宽度同样会被计算在内，当剩余的宽度不足以容纳一个 fill 时，以空格填充。
+//
+// s 的宽度由 [WidthOptions.VisibleWidth] 计算，其中的转义序列不占宽度。
+func (wo WidthOptions) PadLeft(s string, w int, fill rune) string {
+	left, _ := wo.padding(w-wo.VisibleWidth(s), 0, fill)
+	return left + s
+}
+
+// PadRight 在 s 的右侧填充 fill 使其宽度达到 w
+//
+// 即左对齐，其它说明可参考 [WidthOptions.PadLeft]。
+func (wo WidthOptions) PadRight(s string, w int, fill rune) string {
+	_, right := wo.padding(0, w-wo.VisibleWidth(s), fill)
+	return s + right
+}
+
+// PadCenter 在 s 的两侧填充 fill 使其宽度达到 w
+//
+// 即居中对齐，不能平分时，右侧多填充一些。其它说明可参考 [WidthOptions.PadLeft]。
+func (wo WidthOptions) PadCenter(s string, w int, fill rune) string {
+	n := w - wo.VisibleWidth(s)
+	left, right := wo.padding(n/2, n-n/2, fill)
+	return left + s + right
+}
+
+// 分别返回宽度为 left 和 right 的填充内容
+func (wo WidthOptions) padding(left, right int, fill rune) (string, string) {
+	return wo.fill(left, fill), wo.fill(right, fill)
+}
+
+func (wo WidthOptions) fill(n int, fill rune) string {
+	if n <= 0 {
+		return ""
+	}
+
+	fw := wo.runeWidth(fill)
+	if fw <= 0 {
+		return strings.Repeat(" ", n)
+	}
+	return strings.Repeat(string(fill), n/fw) + strings.Repeat(" ", n%fw)
+}
+
+// Align 将 v 包装为按显示宽度对齐的 [fmt.Formatter] 对象
+//
+// 在 [fmt.Printf] 等函数中，宽度是按字符数量计算的，
+// 对于中日韩等宽字符无法正确对齐，经 Align 包装之后，
+// 诸如 %-20v 和 %20s 等格式中的宽度将按 wo 计算的显示宽度处理：
+//
+//	fmt.Printf("%-10v|", wo.Align("汉字")) // 输出 "汉字      |"
+//
+// 除宽度之外的其它格式化参数保持不变，包含 0 标记时不作处理。
+func (wo WidthOptions) Align(v any) fmt.Formatter { return &aligned{wo: wo, v: v} }
+
+func (a *aligned) Format(f fmt.State, verb rune) {
+	w, ok := f.Width()
+	if !ok || f.Flag('0') {
+		fmt.Fprintf(f, fmt.FormatString(f, verb), a.v)
+		return
+	}
+
+	b := strings.Builder{}
+	b.WriteByte('%')
+	for _, flag := range "+# " {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+	if p, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(p))
+	}
+	b.WriteRune(verb)
+
+	s := fmt.Sprintf(b.String(), a.v)
+	if f.Flag('-') {
+		s = a.wo.PadRight(s, w, ' ')
+	} else {
+		s = a.wo.PadLeft(s, w, ' ')
+	}
+	fmt.Fprint(f, s)
+}
+
+// PadLeft 采用 [DefaultWidthOptions] 在 s 的左侧填充 fill
+//
+// 具体说明可参考 [WidthOptions.PadLeft]。
+func PadLeft(s string, w int, fill rune) string { return defaultWidthOptions.PadLeft(s, w, fill) }
+
+// PadRight 采用 [DefaultWidthOptions] 在 s 的右侧填充 fill
+//
+// 具体说明可参考 [WidthOptions.PadRight]。
+func PadRight(s string, w int, fill rune) string { return defaultWidthOptions.PadRight(s, w, fill) }
+
+// PadCenter 采用 [DefaultWidthOptions] 在 s 的两侧填充 fill
+//
+// 具体说明可参考 [WidthOptions.PadCenter]。
+func PadCenter(s string, w int, fill rune) string { return defaultWidthOptions.PadCenter(s, w, fill) }
+
+// Align 采用 [DefaultWidthOptions] 包装 v
+//
+// 具体说明可参考 [WidthOptions.Align]。
+func Align(v any) fmt.Formatter { return defaultWidthOptions.Align(v) }
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+var _ fmt.Formatter = &aligned{}
+
+func TestPad(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(PadLeft("汉字", 6, ' '), "  汉字").
+		Equal(PadLeft("汉字", 4, ' '), "汉字").
+		Equal(PadLeft("汉字", 2, ' '), "汉字").
+		Equal(PadLeft("abc", 6, '-'), "---abc")
+
+	a.Equal(PadRight("汉字", 6, ' '), "汉字  ").
+		Equal(PadRight("汉字a", 6, '.'), "汉字a.")
+
+	a.Equal(PadCenter("汉字", 7, ' '), " 汉字  ").
+		Equal(PadCenter("汉字", 8, '*'), "**汉字**")
+
+	// 宽字符作为填充内容
+	a.Equal(PadRight("a", 6, '－'), "a－－ ").
+		Equal(PadLeft("a", 5, '－'), "－－a")
+}
+
+func TestAlign(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(fmt.Sprintf("%-6v|", Align("汉字")), "汉字  |").
+		Equal(fmt.Sprintf("%6v|", Align("汉字")), "  汉字|").
+		Equal(fmt.Sprintf("%6s|", Align("abc")), "   abc|").
+		Equal(fmt.Sprintf("%v|", Align("汉字")), "汉字|").
+		Equal(fmt.Sprintf("%-6.1s|", Align("汉字")), "汉    |").
+		Equal(fmt.Sprintf("%+6d|", Align(5)), "    +5|").
+		Equal(fmt.Sprintf("%06d|", Align(5)), "000005|").
+		Equal(fmt.Sprintf("%6q|", Align("字")), "  \"字\"|")
+}
@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+var (
+	// BorderNone 无边框，列之间以两个空格分隔。
+	BorderNone = &TableBorder{Vertical: "  "}
+
+	// BorderPlain 以 ASCII 字符组成的边框
+	BorderPlain = &TableBorder{
+		Horizontal: "-", Vertical: "|",
+		TopLeft: "+", TopMid: "+", TopRight: "+",
+		MidLeft: "+", MidMid: "+", MidRight: "+",
+		BottomLeft: "+", BottomMid: "+", BottomRight: "+",
+	}
+
+	// BorderBox 以制表符组成的边框
+	//
+	// NOTE: 制表符属于 [width.EastAsianAmbiguous]，
+	// 在 [WidthOptions] 中该值不为 1 时可能无法正常显示。
+	BorderBox = &TableBorder{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "┌", TopMid: "┬", TopRight: "┐",
+		MidLeft: "├", MidMid: "┼", MidRight: "┤",
+		BottomLeft: "└", BottomMid: "┴", BottomRight: "┘",
+	}
+)
+
+type (
+	// Alignment 对齐方式
+	Alignment int8
+
+	// TableBorder 表格的边框
+	TableBorder struct {
+		// 水平线，只能是单个字符。
+		//
+		// 如果为空，表示没有外边框和水平线，此时 Vertical 仅作为列之间的分隔符。
+		Horizontal string
+
+		// 列之间的分隔线
+		Vertical string
+
+		TopLeft, TopMid, TopRight          string
+		MidLeft, MidMid, MidRight          string
+		BottomLeft, BottomMid, BottomRight string
+	}
+
+	// Column 表格中列的定义
+	Column struct {
+		// 标题
+		//
+		// 类型可以是 string、[Stringer] 或是 [fmt.Stringer]，其它类型则采用 [fmt.Sprint] 进行转换。
+		// 如果所有列的标题都为 nil，则不输出标题行。
+		Title any
+
+		// 对齐方式
+		Align Alignment
+
+		// 最大宽度
+		//
+		// 如果内容的宽度超过此值，将被截断或是换行，0 表示不限制。
+		MaxWidth int
+
+		// 超过 MaxWidth 时是否换行，否则截断内容并以 … 结尾。
+		Wrap bool
+	}
+
+	// Table 按显示宽度对齐的表格
+	//
+	// 用于在终端输出包含中日韩等宽字符的表格。
+	Table struct {
+		wo      WidthOptions
+		p       *Printer
+		border  *TableBorder
+		columns []*Column
+		rows    [][]any
+	}
+)
+
+// NewTable 声明 [Table] 对象
+//
+// p 用于本地化 [Stringer] 类型的内容，可以为空；
+// border 为表格的边框，如果为空，则采用 [BorderNone]；
+// cols 为列的定义，如果行的单元格数量多于 cols，多出的列采用 Column 的零值；
+func (wo WidthOptions) NewTable(p *Printer, border *TableBorder, cols ...*Column) *Table {
+	if border == nil {
+		border = BorderNone
+	}
+
+	return &Table{
+		wo:      wo,
+		p:       p,
+		border:  border,
+		columns: cols,
+		rows:    make([][]any, 0, 10),
+	}
+}
+
+// AddRow 添加一行内容
+//
+// cells 中的元素类型可参考 [Column.Title]。
+func (t *Table) AddRow(cells ...any) *Table {
+	t.rows = append(t.rows, cells)
+	return t
+}
+
+// Render 输出表格至 w
+func (t *Table) Render(w io.Writer) error {
+	_, err := io.WriteString(w, t.String())
+	return err
+}
+
+func (t *Table) String() string {
+	cols := len(t.columns)
+	for _, row := range t.rows {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	columns := make([]*Column, cols)
+	for i := range columns {
+		if i < len(t.columns) && t.columns[i] != nil {
+			columns[i] = t.columns[i]
+		} else {
+			columns[i] = &Column{}
+		}
+	}
+
+	// 将所有的单元格转换为多行文本
+
+	rows := make([][][]string, 0, len(t.rows)+1)
+	hasTitle := false
+	title := make([]any, cols)
+	for i, col := range columns {
+		title[i] = col.Title
+		hasTitle = hasTitle || col.Title != nil
+	}
+	if hasTitle {
+		rows = append(rows, t.cells(columns, title))
+	}
+	for _, row := range t.rows {
+		rows = append(rows, t.cells(columns, row))
+	}
+
+	widths := make([]int, cols)
+	for _, row := range rows {
+		for i, lines := range row {
+			for _, line := range lines {
+				widths[i] = max(widths[i], t.wo.VisibleWidth(line))
+			}
+		}
+	}
+
+	b := &strings.Builder{}
+	bd := t.border
+	boxed := bd.Horizontal != ""
+
+	if boxed {
+		t.writeLine(b, widths, bd.TopLeft, bd.TopMid, bd.TopRight)
+	}
+	for i, row := range rows {
+		t.writeRow(b, columns, widths, row)
+		if boxed && i == 0 && hasTitle && len(rows) > 1 {
+			t.writeLine(b, widths, bd.MidLeft, bd.MidMid, bd.MidRight)
+		}
+	}
+	if boxed {
+		t.writeLine(b, widths, bd.BottomLeft, bd.BottomMid, bd.BottomRight)
+	}
+
+	return b.String()
+}
+
+// 将一行中的所有单元格转换为多行文本
+func (t *Table) cells(columns []*Column, row []any) [][]string {
+	cells := make([][]string, len(columns))
+	for i, col := range columns {
+		var s string
+		if i < len(row) {
+			s = t.toString(row[i])
+		}
+
+		lines := strings.Split(s, "\n")
+		if col.MaxWidth > 0 {
+			if col.Wrap {
+				lines = strings.Split(t.wo.Wrap(s, col.MaxWidth, ""), "\n")
+			} else {
+				for j, line := range lines {
+					lines[j] = t.wo.Truncate(line, col.MaxWidth, "…")
+				}
+			}
+		}
+		cells[i] = lines
+	}
+	return cells
+}
+
+func (t *Table) toString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case Stringer:
+		return val.LocaleString(t.p)
+	case fmt.Stringer:
+		return val.String()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+func (t *Table) writeRow(b *strings.Builder, columns []*Column, widths []int, row [][]string) {
+	height := 0
+	for _, lines := range row {
+		height = max(height, len(lines))
+	}
+
+	bd := t.border
+	boxed := bd.Horizontal != ""
+	for l := 0; l < height; l++ {
+		line := &strings.Builder{}
+		if boxed {
+			line.WriteString(bd.Vertical)
+		}
+
+		for i, lines := range row {
+			if i > 0 {
+				line.WriteString(bd.Vertical)
+			}
+
+			var s string
+			if l < len(lines) {
+				s = lines[l]
+			}
+			if boxed {
+				line.WriteByte(' ')
+			}
+			line.WriteString(t.align(s, widths[i], columns[i].Align))
+			if boxed {
+				line.WriteByte(' ')
+			}
+		}
+
+		if boxed {
+			line.WriteString(bd.Vertical)
+			b.WriteString(line.String())
+		} else {
+			b.WriteString(strings.TrimRight(line.String(), " "))
+		}
+		b.WriteByte('\n')
+	}
+}
+
+func (t *Table) align(s string, w int, a Alignment) string {
+	switch a {
+	case AlignRight:
+		return t.wo.PadLeft(s, w, ' ')
+	case AlignCenter:
+		return t.wo.PadCenter(s, w, ' ')
+	default:
+		return t.wo.PadRight(s, w, ' ')
+	}
+}
+
+// 输出水平线
+func (t *Table) writeLine(b *strings.Builder, widths []int, left, mid, right string) {
+	h := []rune(t.border.Horizontal)[0]
+
+	b.WriteString(left)
+	for i, w := range widths {
+		if i > 0 {
+			b.WriteString(mid)
+		}
+		b.WriteString(t.wo.fill(w+2, h))
+	}
+	b.WriteString(right)
+	b.WriteByte('\n')
+}
+
+// NewTable 采用 [DefaultWidthOptions] 声明 [Table] 对象
+//
+// 具体说明可参考 [WidthOptions.NewTable]。
+func NewTable(p *Printer, border *TableBorder, cols ...*Column) *Table {
+	return defaultWidthOptions.NewTable(p, border, cols...)
+}
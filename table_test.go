@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+func TestTable(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(NewTable(nil, nil).String(), "")
+
+	tab := NewTable(nil, nil,
+		&Column{Title: "name"},
+		&Column{Title: "值", Align: AlignRight},
+	).AddRow("汉字", 1).AddRow("abc", 100)
+	a.Equal(tab.String(), "name   值\n汉字    1\nabc   100\n")
+
+	tab = NewTable(nil, BorderPlain,
+		&Column{Title: "name"},
+		&Column{Title: "值", Align: AlignCenter},
+	).AddRow("汉字", 1).AddRow("abc", "中文字")
+	a.Equal(tab.String(), `+------+--------+
+| name |   值   |
++------+--------+
+| 汉字 |   1    |
+| abc  | 中文字 |
++------+--------+
+`)
+
+	// 无标题，多出的列
+	tab = NewTable(nil, BorderBox).AddRow("a", "b").AddRow("汉字")
+	a.Equal(tab.String(), `┌──────┬───┐
+│ a    │ b │
+│ 汉字 │   │
+└──────┴───┘
+`)
+
+	// 截断和换行
+	tab = NewTable(nil, BorderNone,
+		&Column{MaxWidth: 5},
+		&Column{MaxWidth: 5, Wrap: true},
+	).AddRow("汉字汉字", "汉字汉字").AddRow("a\nb", "c")
+	a.Equal(tab.String(), "汉字…  汉字\n       汉字\na      c\nb\n")
+
+	buf := &bytes.Buffer{}
+	a.NotError(tab.Render(buf)).Equal(buf.String(), tab.String())
+}
+
+func TestTable_Stringer(t *testing.T) {
+	a := assert.New(t, false)
+
+	b := catalog.NewBuilder()
+	a.NotError(b.SetString(language.SimplifiedChinese, "name", "名称")).
+		NotError(b.SetString(language.SimplifiedChinese, "value %d", "值 %d"))
+	p := message.NewPrinter(language.SimplifiedChinese, message.Catalog(b))
+
+	tab := NewTable(p, nil, &Column{Title: Phrase("name")}).
+		AddRow(Phrase("value %d", 5))
+	a.Equal(tab.String(), "名称\n值 5\n")
+
+	tab = NewTable(nil, nil, &Column{Title: Phrase("name")}).
+		AddRow(Phrase("value %d", 5))
+	a.Equal(tab.String(), "name\nvalue 5\n")
+}
@@ -5,9 +5,18 @@
 package localeutil
 
 import (
+	"iter"
 	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"golang.org/x/text/language"
 	"golang.org/x/text/width"
+
+	"github.com/issue9/localeutil/internal/grapheme"
 )
 
 var defaultWidthOptions = WidthOptions{
@@ -32,6 +41,11 @@ var defaultWidthOptions = WidthOptions{
 //   - [width.EastAsianAmbiguous]: 1
 //
 // 对于 [width.EastAsianAmbiguous] 不同的字体可能有不同的设置。
+//
+// 宽度是以扩展字符簇（UAX #29）为单位计算的，每个字符簇只取其首字符的类别：
+//   - 组合字符、ZWJ 等不会增加宽度，ZWJ 连接的 emoji 序列只计算一次；
+//   - 成对的区域指示符（国旗）以 [width.EastAsianWide] 计算；
+//   - 带 VS16 的字符以 [width.EastAsianWide] 计算，带 VS15 的则以 [width.EastAsianNarrow] 计算；
 type WidthOptions map[width.Kind]int
 
 // DefaultWidthOptions 返回默认的配置项的副本
@@ -39,15 +53,367 @@ type WidthOptions map[width.Kind]int
 // 如果要基于默认值作修改，可以采用此方法。
 func DefaultWidthOptions() WidthOptions { return maps.Clone(defaultWidthOptions) }
 
+// NewWidthOptions 根据语言和终端的环境变量生成 [WidthOptions]
+//
+// 除 [width.EastAsianAmbiguous] 之外，其它值与 [DefaultWidthOptions] 相同，
+// [width.EastAsianAmbiguous] 按以下顺序确定：
+//   - 环境变量 RUNEWIDTH_EASTASIAN，1 表示宽度为 2，0 表示宽度为 1；
+//   - 按顺序读取 LC_ALL、LC_CTYPE 和 LANG 环境变量，
+//     如果包含 @cjk_narrow 修饰符，宽度为 1；
+//     如果编码为中日韩的传统编码，比如 zh_CN.GB18030、ja_JP.eucJP 等，宽度为 2；
+//   - tag 为中文、日文或是韩文时宽度为 2，否则为 1；
+//
+// tag 一般为 [DetectUserLanguageTag] 的返回值。
+func NewWidthOptions(tag language.Tag) WidthOptions { return newWidthOptions(tag, os.Getenv) }
+
+func newWidthOptions(tag language.Tag, getenv func(string) string) WidthOptions {
+	wo := DefaultWidthOptions()
+	if eastAsian(tag, getenv) {
+		wo[width.EastAsianAmbiguous] = 2
+	}
+	return wo
+}
+
+// 中日韩的传统编码
+var cjkCodesets = []string{
+	"gb2312", "gbk", "gb18030", "big5", "big5hkscs", "euccn", "euctw",
+	"eucjp", "sjis", "shiftjis", "iso2022jp", "euckr", "cp949", "uhc", "johab",
+}
+
+func eastAsian(tag language.Tag, getenv func(string) string) bool {
+	switch getenv("RUNEWIDTH_EASTASIAN") {
+	case "1":
+		return true
+	case "0":
+		return false
+	}
+
+	for _, env := range [...]string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		l, err := ParsePOSIXLocale(getenv(env))
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(l.Modifier, "cjk_narrow") {
+			return false
+		}
+
+		codeset := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(l.Codeset))
+		if slices.Contains(cjkCodesets, codeset) {
+			return true
+		}
+		break
+	}
+
+	switch base, _ := tag.Base(); base.String() {
+	case "zh", "ja", "ko":
+		return true
+	default:
+		return false
+	}
+}
+
+// 不可再分割的显示单元
+type segment struct {
+	s   string
+	w   int
+	esc bool // 是否为转义序列或控制字符
+}
+
+// 以字符簇为单位计算宽度
+type clusterWidth struct {
+	weights weights
+	tab     *[tableSize]runeInfo
+	breaker grapheme.Breaker
+	prop    grapheme.Property // 当前字符簇首字符的属性
+	w       int               // 当前字符簇的宽度
+}
+
 // Width 计算字符串的宽度
 func (wo WidthOptions) Width(s string) (w int) {
+	if isPrintableASCII(s) {
+		return len(s) * wo[width.EastAsianNarrow]
+	}
+
+	cw := wo.newClusterWidth()
 	for _, r := range s {
-		w += wo[width.LookupRune(r).Kind()]
+		prev, _ := cw.add(r)
+		w += prev
+	}
+	return w + cw.w
+}
+
+// WidthBytes 计算 b 的宽度
+//
+// 与 [WidthOptions.Width] 相同，但是不需要将 b 转换为字符串。
+func (wo WidthOptions) WidthBytes(b []byte) (w int) {
+	if isPrintableASCII(b) {
+		return len(b) * wo[width.EastAsianNarrow]
+	}
+
+	cw := wo.newClusterWidth()
+	for len(b) > 0 {
+		r, n := utf8.DecodeRune(b)
+		prev, _ := cw.add(r)
+		w += prev
+		b = b[n:]
+	}
+	return w + cw.w
+}
+
+// RuneWidth 计算单个字符的宽度
+//
+// r 被当作一个独立的字符簇，所以组合字符等的宽度为 0。
+func (wo WidthOptions) RuneWidth(r rune) int {
+	info := lookupRune(runeTable(), r)
+	if info.zeroWidth() {
+		return 0
+	}
+	return wo[info.kind()]
+}
+
+func (wo WidthOptions) runeWidth(r rune) int { return wo[lookupRune(runeTable(), r).kind()] }
+
+func (wo WidthOptions) newClusterWidth() clusterWidth {
+	return clusterWidth{weights: wo.weights(), tab: runeTable()}
+}
+
+// 添加字符 r
+//
+// 如果 r 开始了一个新的字符簇，返回前一个字符簇的宽度以及 true，否则返回 0 和 false。
+func (cw *clusterWidth) add(r rune) (prev int, boundary bool) {
+	info := lookupRune(cw.tab, r)
+	p := info.prop()
+	if cw.breaker.NextProperty(p) {
+		prev = cw.w
+		cw.prop = p
+		if info.zeroWidth() { // 孤立的组合字符
+			cw.w = 0
+		} else {
+			cw.w = cw.weights[info.kind()]
+		}
+		return prev, true
+	}
+
+	switch {
+	case r == grapheme.VS16:
+		cw.w = cw.weights[width.EastAsianWide]
+	case r == grapheme.VS15:
+		cw.w = cw.weights[width.EastAsianNarrow]
+	case p == grapheme.RegionalIndicator && cw.prop == grapheme.RegionalIndicator:
+		cw.w = cw.weights[width.EastAsianWide]
+	}
+	return 0, false
+}
+
+func zeroWidth(r rune) bool {
+	return r == '\u200c' || r == '\u200d' || unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// VisibleWidth 计算字符串在终端中的显示宽度
+//
+// 与 [WidthOptions.Width] 的不同之处在于，
+// 会忽略 CSI 和 OSC 等转义序列（包括 OSC 8 超链接）以及 C0 和 C1 控制字符，
+// 适用于计算带颜色等格式的终端输出。
+func (wo WidthOptions) VisibleWidth(s string) (w int) {
+	for seg := range wo.segmentSeq(s) {
+		w += seg.w
 	}
 	return w
 }
 
+// 将 s 拆分为不可再分割的显示单元
+//
+// 转义序列和控制字符作为单独的显示单元，其宽度为 0。
+func (wo WidthOptions) segmentSeq(s string) iter.Seq[segment] {
+	return func(yield func(segment) bool) {
+		cw := wo.newClusterWidth()
+		start := 0
+		for i := 0; i < len(s); {
+			if n := escapeLen(s[i:]); n > 0 {
+				if start < i && !yield(segment{s: s[start:i], w: cw.w}) {
+					return
+				}
+				if !yield(segment{s: s[i : i+n], esc: true}) {
+					return
+				}
+				cw = wo.newClusterWidth()
+				i += n
+				start = i
+				continue
+			}
+
+			r, n := utf8.DecodeRuneInString(s[i:])
+			if prev, ok := cw.add(r); ok && start < i {
+				if !yield(segment{s: s[start:i], w: prev}) {
+					return
+				}
+				start = i
+			}
+			i += n
+		}
+
+		if start < len(s) {
+			yield(segment{s: s[start:], w: cw.w})
+		}
+	}
+}
+
+func (wo WidthOptions) segments(s string) []segment { return slices.Collect(wo.segmentSeq(s)) }
+
+// Truncate 从尾部截断 s 使其宽度不超过 max
+//
+// 如果 s 的宽度超过了 max，会截断 s 的尾部并以 tail 代替，比如 "…"，
+// tail 的宽度也计算在 max 之内。截断时不会将宽字符一分为二，
+// 所以返回值的宽度可能会小于 max。
+// 如果 tail 的宽度本身已经超过 max，那么返回截断之后的 tail。
+//
+// 宽度由 [WidthOptions.VisibleWidth] 计算，被截断部分中的转义序列会被完整地保留，
+// 以保证诸如颜色的重置等操作依然有效。
+func (wo WidthOptions) Truncate(s string, max int, tail string) string {
+	return wo.truncate(s, max, tail, truncateEnd)
+}
+
+// TruncateStart 从头部截断 s 使其宽度不超过 max
+//
+// 与 [WidthOptions.Truncate] 相同，但是截断的是 s 的头部，tail 也会添加在头部。
+func (wo WidthOptions) TruncateStart(s string, max int, tail string) string {
+	return wo.truncate(s, max, tail, truncateStart)
+}
+
+// TruncateMiddle 从中间截断 s 使其宽度不超过 max
+//
+// 与 [WidthOptions.Truncate] 相同，但是截断的是 s 的中间部分，
+// 适用于路径等首尾都比较重要的内容。
+func (wo WidthOptions) TruncateMiddle(s string, max int, tail string) string {
+	return wo.truncate(s, max, tail, truncateMiddle)
+}
+
+const (
+	truncateEnd = iota
+	truncateStart
+	truncateMiddle
+)
+
+func (wo WidthOptions) truncate(s string, max int, tail string, pos int) string {
+	if max <= 0 {
+		return ""
+	}
+
+	segs := wo.segments(s)
+	if segmentsWidth(segs) <= max {
+		return s
+	}
+
+	tw := wo.VisibleWidth(tail)
+	if tw >= max {
+		return wo.truncate(tail, max, "", truncateEnd)
+	}
+	avail := max - tw
+
+	switch pos {
+	case truncateStart:
+		suffix := takeSuffix(segs, avail)
+		dropped := segs[:len(segs)-len(suffix)]
+		return joinEscapes(dropped) + tail + joinSegments(suffix)
+	case truncateMiddle:
+		prefix := takePrefix(segs, avail-avail/2)
+		suffix := takeSuffix(segs[len(prefix):], avail-segmentsWidth(prefix))
+		dropped := segs[len(prefix) : len(segs)-len(suffix)]
+		return joinSegments(prefix) + joinEscapes(dropped) + tail + joinSegments(suffix)
+	default:
+		prefix := takePrefix(segs, avail)
+		return joinSegments(prefix) + tail + joinEscapes(segs[len(prefix):])
+	}
+}
+
+// 从 segs 头部取宽度不超过 max 的内容
+func takePrefix(segs []segment, max int) []segment {
+	w := 0
+	for i, seg := range segs {
+		if w+seg.w > max {
+			return segs[:i]
+		}
+		w += seg.w
+	}
+	return segs
+}
+
+// 从 segs 尾部取宽度不超过 max 的内容
+func takeSuffix(segs []segment, max int) []segment {
+	w := 0
+	for i := len(segs) - 1; i >= 0; i-- {
+		if w+segs[i].w > max {
+			return segs[i+1:]
+		}
+		w += segs[i].w
+	}
+	return segs
+}
+
+func segmentsWidth(segs []segment) (w int) {
+	for _, seg := range segs {
+		w += seg.w
+	}
+	return w
+}
+
+// 只合并 segs 中的转义序列
+func joinEscapes(segs []segment) string {
+	b := strings.Builder{}
+	for _, seg := range segs {
+		if seg.esc {
+			b.WriteString(seg.s)
+		}
+	}
+	return b.String()
+}
+
+func joinSegments(segs []segment) string {
+	b := strings.Builder{}
+	for _, seg := range segs {
+		b.WriteString(seg.s)
+	}
+	return b.String()
+}
+
 // Width 采用 [DefaultWidthOptions] 计算字符串的宽度
 //
 // 如果有特殊要求，可以使用 [WidthOptions] 自定义各类字符的宽度。
 func Width(s string) int { return defaultWidthOptions.Width(s) }
+
+// WidthBytes 采用 [DefaultWidthOptions] 计算 b 的宽度
+//
+// 具体说明可参考 [WidthOptions.WidthBytes]。
+func WidthBytes(b []byte) int { return defaultWidthOptions.WidthBytes(b) }
+
+// RuneWidth 采用 [DefaultWidthOptions] 计算单个字符的宽度
+//
+// 具体说明可参考 [WidthOptions.RuneWidth]。
+func RuneWidth(r rune) int { return defaultWidthOptions.RuneWidth(r) }
+
+// VisibleWidth 采用 [DefaultWidthOptions] 计算字符串在终端中的显示宽度
+//
+// 具体说明可参考 [WidthOptions.VisibleWidth]。
+func VisibleWidth(s string) int { return defaultWidthOptions.VisibleWidth(s) }
+
+// Truncate 采用 [DefaultWidthOptions] 从尾部截断字符串
+//
+// 具体说明可参考 [WidthOptions.Truncate]。
+func Truncate(s string, max int, tail string) string {
+	return defaultWidthOptions.Truncate(s, max, tail)
+}
+
+// TruncateStart 采用 [DefaultWidthOptions] 从头部截断字符串
+//
+// 具体说明可参考 [WidthOptions.TruncateStart]。
+func TruncateStart(s string, max int, tail string) string {
+	return defaultWidthOptions.TruncateStart(s, max, tail)
+}
+
+// TruncateMiddle 采用 [DefaultWidthOptions] 从中间截断字符串
+//
+// 具体说明可参考 [WidthOptions.TruncateMiddle]。
+func TruncateMiddle(s string, max int, tail string) string {
+	return defaultWidthOptions.TruncateMiddle(s, max, tail)
+}
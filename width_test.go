@@ -5,9 +5,11 @@
 package localeutil
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
 	"golang.org/x/text/width"
 )
 
@@ -29,3 +31,207 @@ func TestDefaultWidthOptions(t *testing.T) {
 	a.Equal(defaultWidthOptions[width.EastAsianAmbiguous], 1).
 		Equal(d[width.EastAsianAmbiguous], 5)
 }
+
+func TestTruncate(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Truncate("abcdef", 10, "…"), "abcdef").
+		Equal(Truncate("abcdef", 6, "…"), "abcdef").
+		Equal(Truncate("abcdef", 5, "…"), "abcd…").
+		Equal(Truncate("abcdef", 5, "..."), "ab...").
+		Equal(Truncate("abcdef", 0, "…"), "").
+		Equal(Truncate("abcdef", 2, "..."), "..").
+		Equal(Truncate("abcdef", 3, ""), "abc")
+
+	// 不会拆分宽字符
+	a.Equal(Truncate("汉字汉字", 6, "…"), "汉字…").
+		Equal(Truncate("汉字汉字", 5, "…"), "汉字…").
+		Equal(Truncate("汉字汉字", 4, "…"), "汉…").
+		Equal(Truncate("汉字汉字", 4, "……"), "汉……").
+		Equal(Truncate("a汉字", 3, ""), "a汉")
+
+	a.Equal(TruncateStart("abcdef", 5, "…"), "…cdef").
+		Equal(TruncateStart("汉字汉字", 5, "…"), "…汉字").
+		Equal(TruncateStart("abcdef", 6, "…"), "abcdef")
+
+	a.Equal(TruncateMiddle("/usr/local/bin", 9, "…"), "/usr…/bin").
+		Equal(TruncateMiddle("abcdef", 4, "…"), "ab…f").
+		Equal(TruncateMiddle("汉字汉字", 7, "…"), "汉…汉字").
+		Equal(TruncateMiddle("abcdef", 6, "…"), "abcdef")
+
+	wo := DefaultWidthOptions()
+	wo[width.EastAsianAmbiguous] = 2
+	a.Equal(wo.Truncate("abcdef", 5, "…"), "abc…")
+}
+
+func TestWidth_grapheme(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Width("e\u0301"), 1).
+		Equal(Width("\u0301"), 0).
+		Equal(Width("汉\u0301字"), 4).
+		Equal(Width("😀"), 2).
+		Equal(Width("👍🏽"), 2).
+		Equal(Width("👨\u200d👩\u200d👧"), 2).
+		Equal(Width("🇨🇳🇺🇸"), 4).
+		Equal(Width("❤"), 1).
+		Equal(Width("❤\ufe0f"), 2).
+		Equal(Width("1\ufe0f\u20e3"), 2).
+		Equal(Width("😀\ufe0e"), 1).
+		Equal(Width("각"), 2).
+		Equal(Width("\u1100\u1161\u11a8"), 2)
+
+	wo := DefaultWidthOptions()
+	wo[width.EastAsianWide] = 3
+	a.Equal(wo.Width("❤\ufe0f"), 3).
+		Equal(wo.Width("😀"), 3)
+
+	a.Equal(Truncate("e\u0301e\u0301e\u0301", 2, ""), "e\u0301e\u0301").
+		Equal(Truncate("👨\u200d👩\u200d👧👨\u200d👩\u200d👧", 3, ""), "👨\u200d👩\u200d👧").
+		Equal(TruncateStart("🇨🇳🇺🇸", 3, ""), "🇺🇸")
+}
+
+func TestVisibleWidth(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(VisibleWidth("abc"), 3).
+		Equal(Width("\x1b[31mabc\x1b[0m"), 12).
+		Equal(VisibleWidth("\x1b[31mabc\x1b[0m"), 3).
+		Equal(VisibleWidth("\x1b[31m汉字\x1b[0m"), 4).
+		Equal(VisibleWidth("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"), 4).
+		Equal(VisibleWidth("a\tb\r\n\u0085"), 2).
+		Equal(VisibleWidth("e\x1b[1m\u0301"), 1)
+}
+
+func TestTruncate_escape(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Truncate("\x1b[31mabcdef\x1b[0m", 6, "…"), "\x1b[31mabcdef\x1b[0m").
+		Equal(Truncate("\x1b[31mabcdef\x1b[0m", 4, "…"), "\x1b[31mabc…\x1b[0m").
+		Equal(Truncate("ab\x1b[31mcdef\x1b[0m", 3, "…"), "ab\x1b[31m…\x1b[0m").
+		Equal(TruncateStart("\x1b[31mabcdef\x1b[0m", 4, "…"), "\x1b[31m…def\x1b[0m").
+		Equal(TruncateMiddle("\x1b[31mab\x1b[1mcd\x1b[0mef", 4, "…"), "\x1b[31mab\x1b[1m\x1b[0m…f")
+
+	link := "\x1b]8;;https://example.com\x1b\\"
+	end := "\x1b]8;;\x1b\\"
+	a.Equal(Truncate(link+"汉字汉字"+end, 5, "…"), link+"汉字…"+end)
+
+	a.Equal(PadRight("\x1b[31m汉字\x1b[0m", 6, ' '), "\x1b[31m汉字\x1b[0m  ").
+		Equal(PadLeft("\x1b[31mab\x1b[0m", 4, '.'), "..\x1b[31mab\x1b[0m")
+}
+
+func TestNewWidthOptions(t *testing.T) {
+	a := assert.New(t, false)
+
+	env := func(kv ...string) func(string) string {
+		return func(key string) string {
+			for i := 0; i < len(kv); i += 2 {
+				if kv[i] == key {
+					return kv[i+1]
+				}
+			}
+			return ""
+		}
+	}
+
+	wo := newWidthOptions(language.English, env())
+	a.Equal(wo, defaultWidthOptions)
+
+	wo = newWidthOptions(language.SimplifiedChinese, env())
+	a.Equal(wo[width.EastAsianAmbiguous], 2).Equal(wo[width.EastAsianWide], 2)
+
+	wo = newWidthOptions(language.MustParse("ja-JP"), env("LANG", "ja_JP.UTF-8"))
+	a.Equal(wo[width.EastAsianAmbiguous], 2)
+
+	wo = newWidthOptions(language.English, env("LANG", "zh_CN.GB18030"))
+	a.Equal(wo[width.EastAsianAmbiguous], 2)
+
+	wo = newWidthOptions(language.English, env("LC_CTYPE", "ja_JP.eucJP", "LANG", "en_US.UTF-8"))
+	a.Equal(wo[width.EastAsianAmbiguous], 2)
+
+	// LC_ALL 优先于 LC_CTYPE
+	wo = newWidthOptions(language.English, env("LC_ALL", "en_US.UTF-8", "LC_CTYPE", "ja_JP.eucJP"))
+	a.Equal(wo[width.EastAsianAmbiguous], 1)
+
+	wo = newWidthOptions(language.SimplifiedChinese, env("LANG", "zh_CN.UTF-8@cjk_narrow"))
+	a.Equal(wo[width.EastAsianAmbiguous], 1)
+
+	wo = newWidthOptions(language.SimplifiedChinese, env("RUNEWIDTH_EASTASIAN", "0", "LANG", "zh_CN.GBK"))
+	a.Equal(wo[width.EastAsianAmbiguous], 1)
+
+	wo = newWidthOptions(language.English, env("RUNEWIDTH_EASTASIAN", "1"))
+	a.Equal(wo[width.EastAsianAmbiguous], 2)
+
+	a.NotNil(NewWidthOptions(language.English))
+}
+
+func TestWidthBytes(t *testing.T) {
+	a := assert.New(t, false)
+
+	for _, s := range []string{"", "abc", "汉字３，Ａ", "e\u0301", "🇨🇳🇺🇸", benchMixed} {
+		a.Equal(WidthBytes([]byte(s)), Width(s), s)
+	}
+
+	b := []byte(benchMixed)
+	a.Equal(testing.AllocsPerRun(10, func() { WidthBytes(b) }), 0).
+		Equal(testing.AllocsPerRun(10, func() { Width(benchCJK) }), 0)
+}
+
+func TestRuneWidth(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(RuneWidth('a'), 1).
+		Equal(RuneWidth('汉'), 2).
+		Equal(RuneWidth('\u0301'), 0).
+		Equal(RuneWidth('\u200d'), 0).
+		Equal(RuneWidth('😀'), 2).
+		Equal(RuneWidth('\U000e0100'), 0). // 位于查找表之外
+		Equal(RuneWidth('\U00020000'), 2)
+
+	wo := DefaultWidthOptions()
+	wo[width.EastAsianAmbiguous] = 2
+	a.Equal(wo.RuneWidth('─'), 2).Equal(RuneWidth('─'), 1)
+}
+
+var (
+	benchASCII = strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+	benchCJK   = strings.Repeat("中文字符的宽度计算，日本語の文字幅。", 20)
+	benchMixed = strings.Repeat("Go 语言 width 计算 ✓ café 👍🏽 ", 20)
+)
+
+// 逐字符查找宽度的实现，作为性能比较的基准。
+func lookupRuneWidth(wo WidthOptions, s string) (w int) {
+	for _, r := range s {
+		w += wo[width.LookupRune(r).Kind()]
+	}
+	return w
+}
+
+func BenchmarkWidth(b *testing.B) {
+	data := []struct {
+		name string
+		s    string
+	}{
+		{name: "ascii", s: benchASCII},
+		{name: "cjk", s: benchCJK},
+		{name: "mixed", s: benchMixed},
+	}
+
+	for _, item := range data {
+		b.Run(item.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(item.s)))
+			for b.Loop() {
+				Width(item.s)
+			}
+		})
+
+		b.Run(item.name+"-lookup", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(item.s)))
+			for b.Loop() {
+				lookupRuneWidth(defaultWidthOptions, item.s)
+			}
+		})
+	}
+}
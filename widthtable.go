@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"sync"
+
+	"golang.org/x/text/width"
+
+	"github.com/issue9/localeutil/internal/grapheme"
+)
+
+// 字符的宽度类别以及字符簇属性
+//
+//	0-2 位为 [width.Kind]；
+//	3-6 位为 [grapheme.Property]；
+//	7 位表示是否为零宽度的组合字符；
+type runeInfo uint8
+
+// 各个 [width.Kind] 对应的宽度，以 [width.Kind] 作为下标。
+type weights [width.EastAsianHalfwidth + 1]int
+
+// 查找表覆盖的字符范围，包含 BMP 和 SMP（emoji 位于此平面）。
+const tableSize = 0x20000
+
+// [tableSize] 范围内所有字符的 [runeInfo]，在第一次使用时生成。
+var runeTable = sync.OnceValue(func() *[tableSize]runeInfo {
+	t := &[tableSize]runeInfo{}
+	for r := range t {
+		t[r] = newRuneInfo(rune(r))
+	}
+	return t
+})
+
+func newRuneInfo(r rune) runeInfo {
+	p := grapheme.Lookup(r)
+	info := runeInfo(width.LookupRune(r).Kind()) | runeInfo(p)<<3
+	if (p == grapheme.Extend || p == grapheme.ZWJ) && zeroWidth(r) {
+		info |= 1 << 7
+	}
+	return info
+}
+
+func (info runeInfo) kind() width.Kind { return width.Kind(info & 0b111) }
+
+func (info runeInfo) prop() grapheme.Property { return grapheme.Property(info >> 3 & 0b1111) }
+
+func (info runeInfo) zeroWidth() bool { return info&(1<<7) != 0 }
+
+func lookupRune(tab *[tableSize]runeInfo, r rune) runeInfo {
+	if r >= 0 && r < tableSize {
+		return tab[r]
+	}
+	return newRuneInfo(r)
+}
+
+func (wo WidthOptions) weights() weights {
+	return weights{
+		width.Neutral:            wo[width.Neutral],
+		width.EastAsianAmbiguous: wo[width.EastAsianAmbiguous],
+		width.EastAsianWide:      wo[width.EastAsianWide],
+		width.EastAsianNarrow:    wo[width.EastAsianNarrow],
+		width.EastAsianFullwidth: wo[width.EastAsianFullwidth],
+		width.EastAsianHalfwidth: wo[width.EastAsianHalfwidth],
+	}
+}
+
+// 如果 s 全部由可打印的 ASCII 字符组成，返回 true。
+func isPrintableASCII[T ~string | ~[]byte](s T) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
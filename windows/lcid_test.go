@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/issue9/assert/v4"
+	"golang.org/x/text/language"
+)
+
+func TestIndex(t *testing.T) {
+	a := assert.New(t, false)
+
+	i := index()
+	a.Equal(len(i.tags), len(neutralLCIDs)+len(specificLCIDs)+len(sortLCIDs)).
+		Equal(len(i.lcids), len(i.tags)) // 不存在重复的值
+
+	// 所有值都可以双向转换
+	for lcid, tag := range i.tags {
+		t, found := LCIDToTag(lcid)
+		a.True(found).Equal(t, tag)
+		a.Equal(TagToLCID(tag), lcid, "%s: %x", tag, lcid)
+	}
+}
+
+func TestLCIDToTag(t *testing.T) {
+	a := assert.New(t, false)
+
+	tag, found := LCIDToTag(0x0804)
+	a.True(found).Equal(tag, language.MustParse("zh-CN"))
+
+	tag, found = LCIDToTag(0x0009)
+	a.True(found).Equal(tag, language.English)
+
+	tag, found = LCIDToTag(0x7C04)
+	a.True(found).Equal(tag, language.TraditionalChinese)
+
+	tag, found = LCIDToTag(0x00020804)
+	a.True(found).Equal(tag, language.MustParse("zh-CN-u-co-stroke"))
+
+	tag, found = LCIDToTag(0x0000040A)
+	a.True(found).Equal(tag, language.MustParse("es-ES-u-co-trad"))
+
+	tag, found = LCIDToTag(LocaleCustomUnspecified)
+	a.False(found).Equal(tag, language.Und)
+}
+
+func TestTagToLCID(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(TagToLCID(language.MustParse("zh-CN")), 0x0804).
+		Equal(TagToLCID(language.MustParse("zh-Hans-CN")), 0x0804).
+		Equal(TagToLCID(language.MustParse("zh-Hant-TW")), 0x0404).
+		Equal(TagToLCID(language.MustParse("zh-Hans")), 0x0004).
+		Equal(TagToLCID(language.MustParse("en")), 0x0009).
+		Equal(TagToLCID(language.MustParse("en-US-u-nu-latn")), 0x0409).
+		Equal(TagToLCID(language.MustParse("de-DE-u-co-phonebk")), 0x00010407).
+		Equal(TagToLCID(language.MustParse("sr-RS")), 0x281A). // sr-Cyrl-RS
+		Equal(TagToLCID(language.MustParse("en-150")), LocaleCustomUnspecified).
+		Equal(TagToLCID(language.Und), LocaleCustomUnspecified)
+}
@@ -4,7 +4,6 @@
 
 //go:build windows
 
-// Package windows 与 windows 系统相关的一些本地化操作函数
 package windows
 
 import (
@@ -15,6 +14,9 @@ import (
 )
 
 // GetLCID 将一个符合 BCP47 的名称转换成 LCID
+//
+// 调用的是系统的 LocaleNameToLCID 接口，只能用于 windows 平台，
+// 跨平台的实现可以使用 [TagToLCID]。
 func GetLCID(bcp string) (uint32, error) {
 	f := dll.Kernel32().NewProc("LocaleNameToLCID")
 	name, err := syscall.UTF16FromString(bcp)
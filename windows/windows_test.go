@@ -27,3 +27,20 @@ func TestGetLCID(t *testing.T) {
 	lcid, err = GetLCID("cmn-hans")
 	a.NotError(err).Equal(lcid, 4096)
 }
+
+// 与系统接口的结果进行比对
+func TestTagToLCID_GetLCID(t *testing.T) {
+	a := assert.New(t, false)
+
+	for lcid, tag := range index().tags {
+		if _, found := specificLCIDs[lcid]; !found {
+			continue
+		}
+
+		l, err := GetLCID(tag.String())
+		if err != nil || l == LocaleCustomUnspecified { // 系统中不存在该本地化信息
+			continue
+		}
+		a.Equal(TagToLCID(tag), l, "%s: %x != %x", tag, lcid, l)
+	}
+}
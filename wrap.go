@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// 换行时字符的类别，是 UAX #14 中类别的简化版本。
+type breakClass int8
+
+const (
+	breakAL breakClass = iota // 普通字符，之间不能换行
+	breakSP                   // 空格
+	breakOP                   // 开始的标点，之后不能换行
+	breakCL                   // 结束的标点，之前不能换行
+	breakHY                   // 连字符，之后可以换行
+	breakID                   // 表意文字，前后都可以换行
+)
+
+const (
+	openPunct  = "([{（［｛「『【〔〈《〖〘〚“‘"
+	closePunct = ")]}!?,.;:%）］｝」』】〕〉》〗〙〛！？，．：；。、・ー～々…‥”’ぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮヵヶ"
+)
+
+// WrapWriter 按显示宽度换行的 [io.Writer] 实现
+//
+// 写入的内容以行为单位进行处理，
+// 最后一行如果没有以换行符结尾，需要调用 [WrapWriter.Flush] 才会输出。
+type WrapWriter struct {
+	w      io.Writer
+	wo     WidthOptions
+	max    int
+	indent string
+	buf    []byte
+}
+
+// 一个不可拆分的单词及其之后的空格
+type word struct {
+	segs   []segment
+	w      int
+	spaces string
+	spaceW int
+}
+
+// Wrap 按显示宽度 max 对 s 进行换行
+//
+// 换行遵守以下规则：
+//   - 原有的换行符会被保留；
+//   - 中日韩等表意文字之间可以换行；
+//   - 不会在诸如 "。" 和 "）" 等结束的标点之前换行，也不会在开始的标点之后换行；
+//   - 拉丁文字的单词不会被拆分，除非单词本身的宽度已经超过了 max；
+//   - 行尾的空格会被删除；
+//
+// indent 为悬挂缩进，除每段的第一行之外，其它行都会以 indent 开头，
+// indent 的宽度也计算在 max 之内，如果 indent 的宽度不小于 max，则忽略 indent。
+// 宽度由 [WidthOptions.VisibleWidth] 计算，如果 max 不大于 0，则原样返回 s。
+func (wo WidthOptions) Wrap(s string, max int, indent string) string {
+	if max <= 0 {
+		return s
+	}
+
+	b := &strings.Builder{}
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		wo.wrapLine(b, line, max, indent)
+	}
+	return b.String()
+}
+
+func (wo WidthOptions) wrapLine(b *strings.Builder, line string, max int, indent string) {
+	indentW := wo.VisibleWidth(indent)
+	if indentW >= max { // 否则除第一行之外的每一行都会超过 max
+		indent = ""
+		indentW = 0
+	}
+	start := 0 // 行首的宽度
+	cur := 0
+	var pending string // 上一个单词之后的空格
+	var pendingW int
+
+	newline := func() {
+		b.WriteByte('\n')
+		b.WriteString(indent)
+		start = indentW
+		cur = indentW
+		pending = ""
+		pendingW = 0
+	}
+
+	for _, wd := range wo.words(line) {
+		switch {
+		case cur == start || cur+pendingW+wd.w <= max:
+			b.WriteString(pending)
+			cur += pendingW
+		case wd.w > 0:
+			newline()
+		}
+
+		for _, seg := range wd.segs {
+			if cur+seg.w > max && cur > start && seg.w > 0 { // 单词本身超过了一行的宽度
+				newline()
+			}
+			b.WriteString(seg.s)
+			cur += seg.w
+		}
+
+		pending = wd.spaces
+		pendingW = wd.spaceW
+	}
+}
+
+// 将 line 拆分为单词
+//
+// 单词之间为可换行的位置，行首的空格作为第一个单词的一部分。
+func (wo WidthOptions) words(line string) []*word {
+	words := make([]*word, 0, 10)
+	var curr *word
+	var prev breakClass
+	leading := true
+
+	for seg := range wo.segmentSeq(line) {
+		if seg.esc {
+			// 转义序列跟随在之前的内容之后，
+			// 如果之前是空格，则作为下一个单词的开始，以免在换行时被丢弃。
+			if curr == nil || curr.spaces != "" {
+				curr = &word{}
+				words = append(words, curr)
+				prev = breakOP // 与之后的内容之间不能换行
+			}
+			curr.segs = append(curr.segs, seg)
+			continue
+		}
+
+		r, _ := utf8.DecodeRuneInString(seg.s)
+		class := lookupBreakClass(r)
+
+		if class == breakSP && !leading {
+			curr.spaces += seg.s
+			curr.spaceW += seg.w
+			prev = class
+			continue
+		}
+
+		if curr == nil || (!leading && canBreak(prev, class)) { // 行首的空格与第一个单词之间不能换行
+			curr = &word{}
+			words = append(words, curr)
+		}
+		curr.segs = append(curr.segs, seg)
+		curr.w += seg.w
+		if class != breakSP {
+			leading = false
+		}
+		prev = class
+	}
+
+	return words
+}
+
+func lookupBreakClass(r rune) breakClass {
+	switch {
+	case r == ' ':
+		return breakSP
+	case r == '-':
+		return breakHY
+	case strings.ContainsRune(openPunct, r):
+		return breakOP
+	case strings.ContainsRune(closePunct, r):
+		return breakCL
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+		return breakID
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return breakID
+	default:
+		return breakAL
+	}
+}
+
+// 在类型为 before 和 after 的字符之间是否可以换行
+func canBreak(before, after breakClass) bool {
+	switch {
+	case after == breakSP || after == breakCL:
+		return false
+	case before == breakOP:
+		return false
+	case before == breakSP:
+		return true
+	case before == breakID || after == breakID:
+		return true
+	case before == breakHY:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewWrapWriter 声明 [WrapWriter] 对象
+//
+// 参数说明可参考 [WidthOptions.Wrap]。
+func (wo WidthOptions) NewWrapWriter(w io.Writer, max int, indent string) *WrapWriter {
+	return &WrapWriter{w: w, wo: wo, max: max, indent: indent}
+}
+
+// Write 写入内容
+//
+// 仅在遇到换行符时才会将之前的内容换行后写入底层的 [io.Writer]。
+// p 总是会被全部接收，即使写入底层的 [io.Writer] 出错，返回值 n 也为 len(p)。
+func (w *WrapWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	index := bytes.LastIndexByte(w.buf, '\n')
+	if index < 0 {
+		return len(p), nil
+	}
+
+	s := w.wo.Wrap(string(w.buf[:index+1]), w.max, w.indent)
+	w.buf = append(w.buf[:0], w.buf[index+1:]...)
+	_, err := io.WriteString(w.w, s)
+	return len(p), err
+}
+
+// Flush 输出缓存中未以换行符结尾的内容
+func (w *WrapWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	s := w.wo.Wrap(string(w.buf), w.max, w.indent)
+	w.buf = w.buf[:0]
+	_, err := io.WriteString(w.w, s)
+	return err
+}
+
+// Wrap 采用 [DefaultWidthOptions] 对 s 进行换行
+//
+// 具体说明可参考 [WidthOptions.Wrap]。
+func Wrap(s string, max int, indent string) string { return defaultWidthOptions.Wrap(s, max, indent) }
+
+// NewWrapWriter 采用 [DefaultWidthOptions] 声明 [WrapWriter] 对象
+//
+// 具体说明可参考 [WidthOptions.Wrap]。
+func NewWrapWriter(w io.Writer, max int, indent string) *WrapWriter {
+	return defaultWidthOptions.NewWrapWriter(w, max, indent)
+}
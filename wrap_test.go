@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2026 caixw
+//
+// SPDX-License-Identifier: MIT
+
+package localeutil
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/issue9/assert/v4"
+)
+
+var _ io.Writer = &WrapWriter{}
+
+func TestWrap(t *testing.T) {
+	a := assert.New(t, false)
+
+	a.Equal(Wrap("hello world", 0, ""), "hello world").
+		Equal(Wrap("hello world", 20, ""), "hello world").
+		Equal(Wrap("hello world", 5, ""), "hello\nworld").
+		Equal(Wrap("hello world", 8, ""), "hello\nworld").
+		Equal(Wrap("hello   world foo", 11, ""), "hello\nworld foo").
+		Equal(Wrap("a b c d", 3, ""), "a b\nc d").
+		Equal(Wrap("  hello world", 8, ""), "  hello\nworld").
+		Equal(Wrap("  leading spaces here", 8, ""), "  leadin\ng spaces\nhere"). // 行首的空格不会单独成行
+		Equal(Wrap("  leading spaces here", 9, ""), "  leading\nspaces\nhere").
+		Equal(Wrap("helloworld", 4, ""), "hell\nowor\nld").
+		Equal(Wrap("well-known word", 6, ""), "well-\nknown\nword").
+		Equal(Wrap("line1\nline2 line2", 6, ""), "line1\nline2\nline2")
+
+	// 中文
+	a.Equal(Wrap("汉字汉字汉字", 4, ""), "汉字\n汉字\n汉字").
+		Equal(Wrap("汉字汉字汉字", 5, ""), "汉字\n汉字\n汉字").
+		Equal(Wrap("你好。世界", 4, ""), "你\n好。\n世界").
+		Equal(Wrap("（你好）世界", 4, ""), "（你\n好）\n世界").
+		Equal(Wrap("中文abc中文", 6, ""), "中文\nabc中\n文").
+		Equal(Wrap("中文 abc def", 7, ""), "中文\nabc def")
+
+	// 悬挂缩进
+	a.Equal(Wrap("hello world foo bar", 9, "  "), "hello\n  world\n  foo bar").
+		Equal(Wrap("汉字汉字汉字", 6, "  "), "汉字汉\n  字汉\n  字").
+		Equal(Wrap("abcdef", 3, "  "), "abc\n  d\n  e\n  f").
+		Equal(Wrap("aaaa bbbb", 3, "    "), "aaa\na\nbbb\nb"). // indent 的宽度超过了 max
+		Equal(Wrap("aa bb", 2, "  "), "aa\nbb")
+
+	// 转义字符
+	a.Equal(Wrap("\x1b[31mhello\x1b[0m world", 5, ""), "\x1b[31mhello\x1b[0m\nworld").
+		Equal(Wrap("hello \x1b[31mworld\x1b[0m", 5, ""), "hello\n\x1b[31mworld\x1b[0m")
+}
+
+func TestWrapWriter(t *testing.T) {
+	a := assert.New(t, false)
+
+	buf := &bytes.Buffer{}
+	w := NewWrapWriter(buf, 5, "")
+	_, err := w.Write([]byte("hello wo"))
+	a.NotError(err).Empty(buf.String())
+
+	_, err = w.Write([]byte("rld\n汉字汉"))
+	a.NotError(err).Equal(buf.String(), "hello\nworld\n")
+
+	_, err = w.Write([]byte("字汉"))
+	a.NotError(err).Equal(buf.String(), "hello\nworld\n")
+
+	a.NotError(w.Flush()).Equal(buf.String(), "hello\nworld\n汉字\n汉字\n汉")
+	a.NotError(w.Flush()).Equal(buf.String(), "hello\nworld\n汉字\n汉字\n汉")
+
+	// 写入出错
+	w = NewWrapWriter(errWriter{}, 5, "")
+	n, err := w.Write([]byte("hello"))
+	a.NotError(err).Equal(n, 5)
+	n, err = w.Write([]byte(" world\n"))
+	a.Equal(err, io.ErrShortWrite).Equal(n, 7)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, io.ErrShortWrite }